@@ -0,0 +1,369 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Nodes are allocated in slabs of a fixed size, so growing an arena never moves existing nodes
+const (
+	arenaSlabBits = 12
+	arenaSlabSize = 1 << arenaSlabBits
+)
+
+// Index of the sentinel node, which stands for every nil link
+const arenaNil = uint32(0)
+
+type arenaNode[K constraints.Ordered, V any] struct {
+	parent uint32
+	left   uint32
+	right  uint32 // Links free nodes into a list
+	color  Color
+	key    K
+	value  V
+}
+
+/*
+ArenaTree is a Red-Black tree, which nodes live in large slices and link to each other by uint32 indices
+instead of pointers. Nodes of pointer-free keys and values contain no pointers at all, so the garbage
+collector does not need to scan them. Removed nodes are kept in a free list and reused by insertions.
+
+Unlike RedBlackTree, it uses a sentinel node, as operations are based on the Introduction to Algorithms as is.
+*/
+type ArenaTree[K constraints.Ordered, V any] struct {
+	slabs [][]arenaNode[K, V]
+	root  uint32
+	free  uint32 // Head of the free list
+	size  int
+}
+
+// Function MakeArena creates empty instance of an arena tree
+func MakeArena[K constraints.Ordered, V any]() *ArenaTree[K, V] {
+	// Index 0 is occupied by the black sentinel
+	return &ArenaTree[K, V]{
+		slabs: [][]arenaNode[K, V]{make([]arenaNode[K, V], 1, arenaSlabSize)},
+	}
+}
+
+func (at *ArenaTree[K, V]) node(i uint32) *arenaNode[K, V] {
+	return &at.slabs[i>>arenaSlabBits][i&(arenaSlabSize-1)]
+}
+
+func (at *ArenaTree[K, V]) alloc(k K, v V) uint32 {
+	if i := at.free; i != arenaNil {
+		n := at.node(i)
+		at.free = n.right
+		*n = arenaNode[K, V]{key: k, value: v, color: red}
+		return i
+	}
+
+	last := len(at.slabs) - 1
+	if len(at.slabs[last]) == arenaSlabSize {
+		if last+1 == 1<<(32-arenaSlabBits) {
+			panic("arena tree is full")
+		}
+		at.slabs = append(at.slabs, make([]arenaNode[K, V], 0, arenaSlabSize))
+		last++
+	}
+
+	at.slabs[last] = append(at.slabs[last], arenaNode[K, V]{key: k, value: v, color: red})
+	return uint32(last<<arenaSlabBits | (len(at.slabs[last]) - 1))
+}
+
+// Function release puts a node into the free list, dropping references held by its key and value
+func (at *ArenaTree[K, V]) release(i uint32) {
+	*at.node(i) = arenaNode[K, V]{right: at.free}
+	at.free = i
+}
+
+// Function Insert puts a value into a tree. If key already exists - function updates it's value
+func (at *ArenaTree[K, V]) Insert(k K, v V) {
+	y, x := arenaNil, at.root
+	for x != arenaNil {
+		y = x
+		n := at.node(x)
+		switch {
+		case k < n.key:
+			x = n.left
+		case k > n.key:
+			x = n.right
+		default:
+			n.value = v
+			return
+		}
+	}
+
+	z := at.alloc(k, v)
+	at.node(z).parent = y
+	if y == arenaNil {
+		at.root = z
+	} else if k < at.node(y).key {
+		at.node(y).left = z
+	} else {
+		at.node(y).right = z
+	}
+
+	at.insertFixup(z)
+	at.size++
+}
+
+// Function Search performs lookup of a value by key. Returns whether key was found
+func (at *ArenaTree[K, V]) Search(k K) (value V, exists bool) {
+	if i := at.search(k); i != arenaNil {
+		return at.node(i).value, true
+	}
+	return
+}
+
+// Function Remove removes given key from a tree, its node is reused by following insertions
+func (at *ArenaTree[K, V]) Remove(k K) {
+	if z := at.search(k); z != arenaNil {
+		at.delete(z)
+		at.release(z)
+		at.size--
+	}
+}
+
+// Function Keys returns a collection of keys in ascending order
+func (at *ArenaTree[K, V]) Keys() []K {
+	result := make([]K, 0, at.size)
+	at.Traverse(func(k K, v V) {
+		result = append(result, k)
+	})
+	return result
+}
+
+// Function Traverse applies a closure to every entry in ascending key order
+func (at *ArenaTree[K, V]) Traverse(closure func(k K, v V)) {
+	var stack []uint32
+	for i := at.root; i != arenaNil || len(stack) > 0; {
+		if i != arenaNil {
+			stack = append(stack, i)
+			i = at.node(i).left
+			continue
+		}
+
+		i = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		n := at.node(i)
+		closure(n.key, n.value)
+		i = n.right
+	}
+}
+
+// Function Size returns a number of elements stored in a tree
+func (at *ArenaTree[K, V]) Size() int {
+	return at.size
+}
+
+func (at *ArenaTree[K, V]) search(k K) uint32 {
+	for x := at.root; x != arenaNil; {
+		n := at.node(x)
+		switch {
+		case k < n.key:
+			x = n.left
+		case k > n.key:
+			x = n.right
+		default:
+			return x
+		}
+	}
+	return arenaNil
+}
+
+func (at *ArenaTree[K, V]) minimum(x uint32) uint32 {
+	for at.node(x).left != arenaNil {
+		x = at.node(x).left
+	}
+	return x
+}
+
+func (at *ArenaTree[K, V]) leftRotate(x uint32) {
+	xn := at.node(x)
+	y := xn.right
+	yn := at.node(y)
+
+	xn.right = yn.left
+	if yn.left != arenaNil {
+		at.node(yn.left).parent = x
+	}
+	at.transplant(x, y)
+	yn.left = x
+	xn.parent = y
+}
+
+func (at *ArenaTree[K, V]) rightRotate(x uint32) {
+	xn := at.node(x)
+	y := xn.left
+	yn := at.node(y)
+
+	xn.left = yn.right
+	if yn.right != arenaNil {
+		at.node(yn.right).parent = x
+	}
+	at.transplant(x, y)
+	yn.right = x
+	xn.parent = y
+}
+
+// Function transplant replaces subtree u with subtree v. Parent of v is set even if v is the sentinel
+func (at *ArenaTree[K, V]) transplant(u, v uint32) {
+	p := at.node(u).parent
+	if p == arenaNil {
+		at.root = v
+	} else if u == at.node(p).left {
+		at.node(p).left = v
+	} else {
+		at.node(p).right = v
+	}
+	at.node(v).parent = p
+}
+
+func (at *ArenaTree[K, V]) insertFixup(z uint32) {
+	for at.node(at.node(z).parent).color == red {
+		p := at.node(z).parent
+		g := at.node(p).parent
+
+		if p == at.node(g).left {
+			if u := at.node(g).right; at.node(u).color == red {
+				at.node(p).color = black
+				at.node(u).color = black
+				at.node(g).color = red
+				z = g
+			} else {
+				if z == at.node(p).right {
+					z = p
+					at.leftRotate(z)
+					p = at.node(z).parent
+				}
+				at.node(p).color = black
+				at.node(g).color = red
+				at.rightRotate(g)
+			}
+		} else {
+			if u := at.node(g).left; at.node(u).color == red {
+				at.node(p).color = black
+				at.node(u).color = black
+				at.node(g).color = red
+				z = g
+			} else {
+				if z == at.node(p).left {
+					z = p
+					at.rightRotate(z)
+					p = at.node(z).parent
+				}
+				at.node(p).color = black
+				at.node(g).color = red
+				at.leftRotate(g)
+			}
+		}
+	}
+
+	at.node(at.root).color = black
+}
+
+func (at *ArenaTree[K, V]) delete(z uint32) {
+	zn := at.node(z)
+	y := z
+	yColor := zn.color
+	var x uint32
+
+	if zn.left == arenaNil {
+		x = zn.right
+		at.transplant(z, zn.right)
+	} else if zn.right == arenaNil {
+		x = zn.left
+		at.transplant(z, zn.left)
+	} else {
+		y = at.minimum(zn.right)
+		yn := at.node(y)
+		yColor = yn.color
+		x = yn.right
+
+		if yn.parent == z {
+			at.node(x).parent = y
+		} else {
+			at.transplant(y, yn.right)
+			yn.right = zn.right
+			at.node(yn.right).parent = y
+		}
+
+		at.transplant(z, y)
+		yn.left = zn.left
+		at.node(yn.left).parent = y
+		yn.color = zn.color
+	}
+
+	if yColor == black {
+		at.deleteFixup(x)
+	}
+}
+
+func (at *ArenaTree[K, V]) deleteFixup(x uint32) {
+	for x != at.root && at.node(x).color == black {
+		p := at.node(x).parent
+
+		if x == at.node(p).left {
+			w := at.node(p).right
+			if at.node(w).color == red {
+				// Case 1: sibling is red
+				at.node(w).color = black
+				at.node(p).color = red
+				at.leftRotate(p)
+				w = at.node(p).right
+			}
+			wn := at.node(w)
+			if at.node(wn.left).color == black && at.node(wn.right).color == black {
+				// Case 2: sibling and its children are black
+				wn.color = red
+				x = p
+			} else {
+				if at.node(wn.right).color == black {
+					// Case 3: sibling is black, sibling's left child is red and sibling's right child is black
+					at.node(wn.left).color = black
+					wn.color = red
+					at.rightRotate(w)
+					w = at.node(p).right
+					wn = at.node(w)
+				}
+				// Case 4: sibling is black, sibling's right child is red
+				wn.color = at.node(p).color
+				at.node(p).color = black
+				at.node(wn.right).color = black
+				at.leftRotate(p)
+				x = at.root
+			}
+		} else {
+			w := at.node(p).left
+			if at.node(w).color == red {
+				// Case 1: sibling is red
+				at.node(w).color = black
+				at.node(p).color = red
+				at.rightRotate(p)
+				w = at.node(p).left
+			}
+			wn := at.node(w)
+			if at.node(wn.left).color == black && at.node(wn.right).color == black {
+				// Case 2: sibling and its children are black
+				wn.color = red
+				x = p
+			} else {
+				if at.node(wn.left).color == black {
+					// Case 3: sibling is black, sibling's right child is red and sibling's left child is black
+					at.node(wn.right).color = black
+					wn.color = red
+					at.leftRotate(w)
+					w = at.node(p).left
+					wn = at.node(w)
+				}
+				// Case 4: sibling is black, sibling's left child is red
+				wn.color = at.node(p).color
+				at.node(p).color = black
+				at.node(wn.left).color = black
+				at.rightRotate(p)
+				x = at.root
+			}
+		}
+	}
+
+	at.node(x).color = black
+}
@@ -0,0 +1,200 @@
+package rbt
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ArenaTreeSuite struct {
+	suite.Suite
+}
+
+// Function blackHeight checks Red-Black and BST properties of a subtree, returning its black height or -1
+func (at *ArenaTree[K, V]) blackHeight(i uint32, minKey, maxKey *K) int {
+	if i == arenaNil {
+		return 1
+	}
+
+	n := at.node(i)
+	if (minKey != nil && !(n.key > *minKey)) || (maxKey != nil && !(n.key < *maxKey)) {
+		return -1
+	}
+	for _, child := range []uint32{n.left, n.right} {
+		if child != arenaNil && at.node(child).parent != i {
+			return -1
+		}
+		if n.color == red && at.node(child).color == red {
+			return -1
+		}
+	}
+
+	left, right := at.blackHeight(n.left, minKey, &n.key), at.blackHeight(n.right, &n.key, maxKey)
+	if left < 0 || left != right {
+		return -1
+	}
+	if n.color == black {
+		left++
+	}
+	return left
+}
+
+func (at *ArenaTree[K, V]) isValid() bool {
+	return at.node(arenaNil).color == black && at.node(at.root).color == black &&
+		(at.root == arenaNil || at.node(at.root).parent == arenaNil) &&
+		at.blackHeight(at.root, nil, nil) > 0 && len(at.Keys()) == at.size
+}
+
+func (suite *ArenaTreeSuite) TestInsertSearchRemove() {
+	tree := MakeArena[int, string]()
+	for _, k := range []int{8, 18, 5, 15, 17, 25, 40, 80, 3, 1, -3, 60} {
+		tree.Insert(k, fmt.Sprint(k))
+		assert.True(suite.T(), tree.isValid())
+	}
+	assert.Equal(suite.T(), []int{-3, 1, 3, 5, 8, 15, 17, 18, 25, 40, 60, 80}, tree.Keys())
+
+	tree.Insert(15, "fifteen")
+	v, found := tree.Search(15)
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), "fifteen", v)
+	assert.Equal(suite.T(), 12, tree.Size())
+
+	for _, k := range []int{8, 80, 60, 5, 1, 100} {
+		tree.Remove(k)
+		assert.True(suite.T(), tree.isValid())
+		_, found := tree.Search(k)
+		assert.False(suite.T(), found)
+	}
+	assert.Equal(suite.T(), []int{-3, 3, 15, 17, 18, 25, 40}, tree.Keys())
+}
+
+func (suite *ArenaTreeSuite) TestFreeListReuse() {
+	tree := MakeArena[int, *int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, &i)
+	}
+	for i := 0; i < 100; i += 2 {
+		tree.Remove(i)
+	}
+
+	// Released nodes hold no references and are reused before an arena grows
+	for i := tree.free; i != arenaNil; i = tree.node(i).right {
+		assert.Nil(suite.T(), tree.node(i).value)
+	}
+	allocated := len(tree.slabs[0])
+	for i := 100; i < 150; i++ {
+		tree.Insert(i, nil)
+	}
+	assert.Equal(suite.T(), allocated, len(tree.slabs[0]))
+	assert.Equal(suite.T(), arenaNil, tree.free)
+	assert.True(suite.T(), tree.isValid())
+}
+
+func (suite *ArenaTreeSuite) TestAgainstRedBlackTree() {
+	rng := rand.New(rand.NewSource(1))
+	arena := MakeArena[int, int]()
+	tree := Make[int, int]()
+
+	// Enough operations to span several slabs
+	for i := 0; i < 4*arenaSlabSize; i++ {
+		k, v := rng.Intn(2*arenaSlabSize), rng.Int()
+		if rng.Intn(3) == 0 {
+			arena.Remove(k)
+			tree.Remove(k)
+		} else {
+			arena.Insert(k, v)
+			tree.Insert(k, v)
+		}
+	}
+	assert.True(suite.T(), arena.isValid())
+	assert.Greater(suite.T(), len(arena.slabs), 1)
+	assert.Equal(suite.T(), tree.Size(), arena.Size())
+
+	var expected, actual [][2]int
+	tree.Traverse(func(k, v int) { expected = append(expected, [2]int{k, v}) })
+	arena.Traverse(func(k, v int) { actual = append(actual, [2]int{k, v}) })
+	assert.Equal(suite.T(), expected, actual)
+
+	for _, k := range tree.Keys() {
+		arena.Remove(k)
+	}
+	assert.Equal(suite.T(), 0, arena.Size())
+	assert.Equal(suite.T(), arenaNil, arena.root)
+	assert.True(suite.T(), arena.isValid())
+}
+
+func TestArenaTreeSuite(t *testing.T) {
+	suite.Run(t, new(ArenaTreeSuite))
+}
+
+func makeFilledArena(x, y int) *ArenaTree[int, int] {
+	tree := MakeArena[int, int]()
+
+	for i := 0; i < x; i++ {
+		for j := 0; j < y; j++ {
+			tree.Insert(i*j+j, 0)
+		}
+	}
+
+	return tree
+}
+
+func BenchmarkArenaTreeInsert(b *testing.B) {
+	for i := iMin; i <= iMax; i++ {
+		n := 1 << i
+		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			tree := MakeArena[int, int]()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StartTimer()
+				for j := 0; j < n; j++ {
+					tree.Insert(i*j+j, 0)
+				}
+				b.StopTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkArenaTreeSearch(b *testing.B) {
+	for i := iMin; i <= iMax; i++ {
+		n := 1 << i
+		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			tree := makeFilledArena(b.N, n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StartTimer()
+				for j := 0; j < n; j++ {
+					tree.Search(i*j + j)
+				}
+				b.StopTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkArenaTreeDelete(b *testing.B) {
+	for i := iMin; i <= iMax; i++ {
+		n := 1 << i
+		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			tree := makeFilledArena(b.N, n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StartTimer()
+				for j := 0; j < n; j++ {
+					tree.Remove(i*j + j)
+				}
+				b.StopTimer()
+			}
+		})
+	}
+}
@@ -0,0 +1,253 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+type avlNode[K constraints.Ordered, V any] struct {
+	left   *avlNode[K, V]
+	right  *avlNode[K, V]
+	key    K
+	value  V
+	height int8 // Height of a subtree, which is 1 for a leaf
+}
+
+func (node *avlNode[K, V]) getHeight() int8 {
+	if node == nil {
+		return 0
+	}
+	return node.height
+}
+
+func (node *avlNode[K, V]) balance() int8 {
+	return node.left.getHeight() - node.right.getHeight()
+}
+
+func (node *avlNode[K, V]) refresh() {
+	node.height = node.left.getHeight()
+	if h := node.right.getHeight(); h > node.height {
+		node.height = h
+	}
+	node.height++
+}
+
+func (node *avlNode[K, V]) rotateLeft() *avlNode[K, V] {
+	r := node.right
+	node.right = r.left
+	r.left = node
+	node.refresh()
+	r.refresh()
+	return r
+}
+
+func (node *avlNode[K, V]) rotateRight() *avlNode[K, V] {
+	l := node.left
+	node.left = l.right
+	l.right = node
+	node.refresh()
+	l.refresh()
+	return l
+}
+
+// Function rebalance restores the AVL property of a node, whose subtrees differ in height by at most 2
+func (node *avlNode[K, V]) rebalance() *avlNode[K, V] {
+	node.refresh()
+
+	switch b := node.balance(); {
+	case b > 1:
+		if node.left.balance() < 0 {
+			node.left = node.left.rotateLeft()
+		}
+		return node.rotateRight()
+	case b < -1:
+		if node.right.balance() > 0 {
+			node.right = node.right.rotateRight()
+		}
+		return node.rotateLeft()
+	}
+	return node
+}
+
+// Function removeMin detaches the node with the smallest key of a subtree, returning the rest of the subtree and the node
+func (node *avlNode[K, V]) removeMin() (*avlNode[K, V], *avlNode[K, V]) {
+	if node.left == nil {
+		return node.right, node
+	}
+
+	var min *avlNode[K, V]
+	node.left, min = node.left.removeMin()
+	return node.rebalance(), min
+}
+
+/*
+AVLTree is an OrderedMap backed by an AVL tree.
+Heights of subtrees of every node differ by at most one, so it is more rigidly balanced
+than a Red-Black tree: lookups are faster, while insertions and removals make more rotations.
+*/
+type AVLTree[K constraints.Ordered, V any] struct {
+	root *avlNode[K, V]
+	size int
+}
+
+// Function MakeAVL creates empty instance of an AVL tree
+func MakeAVL[K constraints.Ordered, V any]() *AVLTree[K, V] {
+	return &AVLTree[K, V]{}
+}
+
+// Function Insert puts a value into a tree. If key already exists - function updates it's value
+func (tree *AVLTree[K, V]) Insert(k K, v V) {
+	tree.root = tree.insert(tree.root, k, v)
+}
+
+func (tree *AVLTree[K, V]) insert(node *avlNode[K, V], k K, v V) *avlNode[K, V] {
+	if node == nil {
+		tree.size++
+		return &avlNode[K, V]{key: k, value: v, height: 1}
+	}
+
+	switch {
+	case k < node.key:
+		node.left = tree.insert(node.left, k, v)
+	case k > node.key:
+		node.right = tree.insert(node.right, k, v)
+	default:
+		node.value = v
+		return node
+	}
+	return node.rebalance()
+}
+
+// Function Search performs lookup of a value by key. Returns whether key was found
+func (tree *AVLTree[K, V]) Search(k K) (value V, exists bool) {
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			n = n.right
+		default:
+			return n.value, true
+		}
+	}
+	return
+}
+
+// Function Remove removes given key from a tree
+func (tree *AVLTree[K, V]) Remove(k K) {
+	tree.root = tree.remove(tree.root, k)
+}
+
+func (tree *AVLTree[K, V]) remove(node *avlNode[K, V], k K) *avlNode[K, V] {
+	if node == nil {
+		return nil
+	}
+
+	switch {
+	case k < node.key:
+		node.left = tree.remove(node.left, k)
+	case k > node.key:
+		node.right = tree.remove(node.right, k)
+	default:
+		tree.size--
+		if node.left == nil {
+			return node.right
+		}
+		if node.right == nil {
+			return node.left
+		}
+
+		// Successor takes the place of the node
+		right, successor := node.right.removeMin()
+		successor.left, successor.right = node.left, right
+		node = successor
+	}
+	return node.rebalance()
+}
+
+// Function Keys returns a collection of keys in ascending order
+func (tree *AVLTree[K, V]) Keys() []K {
+	result := make([]K, 0, tree.size)
+	tree.Traverse(func(k K, v V) {
+		result = append(result, k)
+	})
+	return result
+}
+
+// Function Traverse applies a closure to every entry in ascending key order
+func (tree *AVLTree[K, V]) Traverse(closure func(k K, v V)) {
+	var stack []*avlNode[K, V]
+	for n := tree.root; n != nil || len(stack) > 0; {
+		if n != nil {
+			stack = append(stack, n)
+			n = n.left
+			continue
+		}
+
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		closure(n.key, n.value)
+		n = n.right
+	}
+}
+
+// Function Size returns a number of elements stored in a tree
+func (tree *AVLTree[K, V]) Size() int {
+	return tree.size
+}
+
+// Function Min returns an entry with the smallest key. Returns false if tree is empty
+func (tree *AVLTree[K, V]) Min() (K, V, bool) {
+	n := tree.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n.entry()
+}
+
+// Function Max returns an entry with the largest key. Returns false if tree is empty
+func (tree *AVLTree[K, V]) Max() (K, V, bool) {
+	n := tree.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.entry()
+}
+
+// Function Floor returns an entry with the largest key less than or equal to k. Returns false if there is none
+func (tree *AVLTree[K, V]) Floor(k K) (K, V, bool) {
+	var result *avlNode[K, V]
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			result, n = n, n.right
+		default:
+			return n.entry()
+		}
+	}
+	return result.entry()
+}
+
+// Function Ceiling returns an entry with the smallest key greater than or equal to k. Returns false if there is none
+func (tree *AVLTree[K, V]) Ceiling(k K) (K, V, bool) {
+	var result *avlNode[K, V]
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			result, n = n, n.left
+		case k > n.key:
+			n = n.right
+		default:
+			return n.entry()
+		}
+	}
+	return result.entry()
+}
+
+func (node *avlNode[K, V]) entry() (k K, v V, found bool) {
+	if node == nil {
+		return
+	}
+	return node.key, node.value, true
+}
@@ -0,0 +1,50 @@
+package rbt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Function check verifies order of keys, heights and balance of a subtree
+func (node *avlNode[K, V]) check(minKey, maxKey *K) error {
+	if node == nil {
+		return nil
+	}
+	if (minKey != nil && !(node.key > *minKey)) || (maxKey != nil && !(node.key < *maxKey)) {
+		return fmt.Errorf("key %v is out of order", node.key)
+	}
+	if err := node.left.check(minKey, &node.key); err != nil {
+		return err
+	}
+	if err := node.right.check(&node.key, maxKey); err != nil {
+		return err
+	}
+
+	height := node.height
+	node.refresh()
+	if height != node.height {
+		return fmt.Errorf("height of %v is %d, expected %d", node.key, height, node.height)
+	}
+	if b := node.balance(); b < -1 || b > 1 {
+		return fmt.Errorf("%v is unbalanced by %d", node.key, b)
+	}
+	return nil
+}
+
+func TestOrderedMapAVLTree(t *testing.T) {
+	runOrderedMapSuite(t,
+		func() OrderedMap[int, int] { return MakeAVL[int, int]() },
+		func(m OrderedMap[int, int]) error { return m.(*AVLTree[int, int]).root.check(nil, nil) })
+}
+
+func TestAVLTreeHeight(t *testing.T) {
+	tree := MakeAVL[int, int]()
+	for i := 0; i < 1<<16-1; i++ {
+		tree.Insert(i, i)
+	}
+
+	// Sequential insertions into an AVL tree result in a perfectly balanced tree
+	assert.Equal(t, int8(16), tree.root.height)
+}
@@ -0,0 +1,172 @@
+package rbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash"
+	"hash/crc32"
+	"io"
+)
+
+// Serialized tree starts with a magic and a format version
+var binaryMagic = [...]byte{'R', 'B', 'T'}
+
+const binaryVersion = 1
+
+// Errors reported on decoding of a serialized tree
+var (
+	ErrInvalidFormat      = errors.New("invalid serialized tree")
+	ErrUnsupportedVersion = errors.New("unsupported serialized tree version")
+	ErrChecksum           = errors.New("serialized tree checksum mismatch")
+)
+
+/*
+Function SetCodecs sets codecs used to serialize keys and values of a tree.
+Nil codec resets to DefaultCodec.
+*/
+func (tree *RedBlackTree[K, V]) SetCodecs(keyCodec Codec[K], valueCodec Codec[V]) {
+	tree.keyCodec = keyCodec
+	tree.valueCodec = valueCodec
+}
+
+func (tree *RedBlackTree[K, V]) codecs() (Codec[K], Codec[V]) {
+	return withDefaultCodecs(tree.keyCodec, tree.valueCodec)
+}
+
+func withDefaultCodecs[K any, V any](keyCodec Codec[K], valueCodec Codec[V]) (Codec[K], Codec[V]) {
+	if keyCodec == nil {
+		keyCodec = DefaultCodec[K]()
+	}
+	if valueCodec == nil {
+		valueCodec = DefaultCodec[V]()
+	}
+	return keyCodec, valueCodec
+}
+
+/*
+Function MarshalBinary implements encoding.BinaryMarshaler.
+
+Format consists of a header (magic "RBT" and a version byte), a number of entries as uvarint,
+entries in ascending key order, encoded by tree codecs, and a big-endian CRC-32 (IEEE)
+of all preceding bytes.
+*/
+func (tree *RedBlackTree[K, V]) MarshalBinary() ([]byte, error) {
+	var buf bytes.Buffer
+	h := crc32.NewIEEE()
+	w := io.MultiWriter(&buf, h)
+	keyCodec, valueCodec := tree.codecs()
+
+	w.Write(binaryMagic[:])
+	w.Write([]byte{binaryVersion})
+	w.Write(binary.AppendUvarint(nil, uint64(tree.size)))
+
+	var err error
+	tree.root.inorder(func(n *Node[K, V]) {
+		if err == nil {
+			err = keyCodec.Encode(w, n.key)
+		}
+		if err == nil {
+			err = valueCodec.Encode(w, n.value)
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	buf.Write(binary.BigEndian.AppendUint32(nil, h.Sum32()))
+	return buf.Bytes(), nil
+}
+
+/*
+Function UnmarshalBinary implements encoding.BinaryUnmarshaler.
+It replaces contents of a tree with decoded entries, building a balanced tree in O(n)
+from the sorted stream. On error the tree is left unchanged.
+*/
+func (tree *RedBlackTree[K, V]) UnmarshalBinary(data []byte) error {
+	br := bytes.NewReader(data)
+	r := &checksumReader{r: br, hash: crc32.NewIEEE()}
+	keyCodec, valueCodec := tree.codecs()
+
+	var header [len(binaryMagic) + 1]byte
+	if _, err := io.ReadFull(r, header[:]); err != nil || !bytes.Equal(header[:len(binaryMagic)], binaryMagic[:]) {
+		return fmt.Errorf("%w: bad header", ErrInvalidFormat)
+	}
+	if version := header[len(binaryMagic)]; version != binaryVersion {
+		return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+	}
+
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return fmt.Errorf("%w: bad entry count: %v", ErrInvalidFormat, noEOF(err))
+	}
+	// Every entry takes at least a byte, which bounds memory allocated for a corrupted count
+	if count > uint64(len(data)) {
+		return fmt.Errorf("%w: entry count %d exceeds data length", ErrInvalidFormat, count)
+	}
+
+	nodes := make([]*Node[K, V], 0, count)
+	for i := uint64(0); i < count; i++ {
+		k, err := keyCodec.Decode(r)
+		if err != nil {
+			return fmt.Errorf("%w: entry %d: %v", ErrInvalidFormat, i, err)
+		}
+		v, err := valueCodec.Decode(r)
+		if err != nil {
+			return fmt.Errorf("%w: entry %d: %v", ErrInvalidFormat, i, err)
+		}
+		if i > 0 && !(k > nodes[i-1].key) {
+			return fmt.Errorf("%w: keys are not in ascending order at entry %d", ErrInvalidFormat, i)
+		}
+		nodes = append(nodes, MakeNode(k, v, black))
+	}
+
+	sum := r.hash.Sum32()
+	var expected [4]byte
+	if _, err := io.ReadFull(br, expected[:]); err != nil {
+		return fmt.Errorf("%w: missing checksum", ErrInvalidFormat)
+	}
+	if binary.BigEndian.Uint32(expected[:]) != sum {
+		return ErrChecksum
+	}
+	if br.Len() != 0 {
+		return fmt.Errorf("%w: %d bytes of trailing data", ErrInvalidFormat, br.Len())
+	}
+
+	tree.rebuild(nodes)
+	return nil
+}
+
+// Function GobEncode implements gob.GobEncoder, using the binary format
+func (tree *RedBlackTree[K, V]) GobEncode() ([]byte, error) {
+	return tree.MarshalBinary()
+}
+
+// Function GobDecode implements gob.GobDecoder, using the binary format
+func (tree *RedBlackTree[K, V]) GobDecode(data []byte) error {
+	return tree.UnmarshalBinary(data)
+}
+
+// Reader which computes a checksum and counts all data read through it
+type checksumReader struct {
+	r    CodecReader
+	hash hash.Hash32
+	n    int64
+}
+
+func (cr *checksumReader) Read(p []byte) (int, error) {
+	n, err := cr.r.Read(p)
+	cr.hash.Write(p[:n])
+	cr.n += int64(n)
+	return n, err
+}
+
+func (cr *checksumReader) ReadByte() (byte, error) {
+	b, err := cr.r.ReadByte()
+	if err == nil {
+		cr.hash.Write([]byte{b})
+		cr.n++
+	}
+	return b, err
+}
@@ -0,0 +1,186 @@
+package rbt
+
+import (
+	"bytes"
+	"encoding"
+	"encoding/binary"
+	"encoding/gob"
+	"hash/crc32"
+	"io"
+	"math"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type BinarySuite struct {
+	suite.Suite
+}
+
+var (
+	_ encoding.BinaryMarshaler   = (*RedBlackTree[int, int])(nil)
+	_ encoding.BinaryUnmarshaler = (*RedBlackTree[int, int])(nil)
+	_ gob.GobEncoder             = (*RedBlackTree[int, int])(nil)
+	_ gob.GobDecoder             = (*RedBlackTree[int, int])(nil)
+)
+
+type point struct {
+	X, Y int
+}
+
+type path string
+
+// Encodes integers as fixed-width little-endian values
+type fixedCodec struct{}
+
+func (fixedCodec) Encode(w io.Writer, v int) error {
+	return binary.Write(w, binary.LittleEndian, int64(v))
+}
+
+func (fixedCodec) Decode(r CodecReader) (int, error) {
+	var v int64
+	err := binary.Read(r, binary.LittleEndian, &v)
+	return int(v), err
+}
+
+// Recomputes a trailing checksum of a hand-crafted serialized tree
+func withChecksum(data []byte) []byte {
+	return binary.BigEndian.AppendUint32(data, crc32.ChecksumIEEE(data))
+}
+
+func (suite *BinarySuite) TestRoundTrip() {
+	for _, n := range []int{0, 1, 2, 3, 7, 8, 100, 1000} {
+		tree := Make[int, string]()
+		for i := 0; i < n; i++ {
+			tree.Insert(i*3-n, strconv.Itoa(i))
+		}
+
+		data, err := tree.MarshalBinary()
+		assert.NoError(suite.T(), err)
+
+		loaded := Make[int, string]()
+		loaded.Insert(12345, "replaced")
+		assert.NoError(suite.T(), loaded.UnmarshalBinary(data))
+
+		assert.NoError(suite.T(), loaded.Validate())
+		assert.Equal(suite.T(), tree.Size(), loaded.Size())
+		assert.Equal(suite.T(), tree.Keys(), loaded.Keys())
+		tree.Traverse(func(k int, v string) {
+			actual, _ := loaded.Search(k)
+			assert.Equal(suite.T(), v, actual)
+		})
+	}
+}
+
+func (suite *BinarySuite) TestDefaultCodecTypes() {
+	floats := Make[float32, bool]()
+	floats.Insert(float32(math.Inf(-1)), true)
+	floats.Insert(-1.5, false)
+	floats.Insert(math.MaxFloat32, true)
+
+	data, err := floats.MarshalBinary()
+	assert.NoError(suite.T(), err)
+	loadedFloats := Make[float32, bool]()
+	assert.NoError(suite.T(), loadedFloats.UnmarshalBinary(data))
+	assert.Equal(suite.T(), floats.Keys(), loadedFloats.Keys())
+	v, _ := loadedFloats.Search(-1.5)
+	assert.False(suite.T(), v)
+
+	paths := Make[path, point]()
+	paths.Insert("a/b", point{1, 2})
+	paths.Insert("", point{-3, 4})
+
+	data, err = paths.MarshalBinary()
+	assert.NoError(suite.T(), err)
+	loadedPaths := Make[path, point]()
+	assert.NoError(suite.T(), loadedPaths.UnmarshalBinary(data))
+	p, found := loadedPaths.Search("a/b")
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), point{1, 2}, p)
+	p, _ = loadedPaths.Search("")
+	assert.Equal(suite.T(), point{-3, 4}, p)
+
+	unsigned := Make[uint8, uint64]()
+	unsigned.Insert(255, math.MaxUint64)
+	data, err = unsigned.MarshalBinary()
+	assert.NoError(suite.T(), err)
+
+	// Decoding into a narrower type reports overflow
+	narrow := Make[uint8, uint8]()
+	assert.ErrorIs(suite.T(), narrow.UnmarshalBinary(data), ErrInvalidFormat)
+}
+
+func (suite *BinarySuite) TestCustomCodec() {
+	tree := Make[int, int]()
+	tree.SetCodecs(fixedCodec{}, fixedCodec{})
+	tree.Insert(1, 10)
+	tree.Insert(2, 20)
+
+	data, err := tree.MarshalBinary()
+	assert.NoError(suite.T(), err)
+	// Header, count, two entries of two 8-byte values and a checksum
+	assert.Len(suite.T(), data, 4+1+2*16+4)
+
+	loaded := Make[int, int]()
+	loaded.SetCodecs(fixedCodec{}, fixedCodec{})
+	assert.NoError(suite.T(), loaded.UnmarshalBinary(data))
+	assert.Equal(suite.T(), []int{1, 2}, loaded.Keys())
+
+	loaded.SetCodecs(nil, nil)
+	assert.Error(suite.T(), loaded.UnmarshalBinary(data))
+	assert.Equal(suite.T(), []int{1, 2}, loaded.Keys(), "tree is unchanged on error")
+}
+
+func (suite *BinarySuite) TestGob() {
+	tree := Make[string, int]()
+	for i := 0; i < 50; i++ {
+		tree.Insert(strconv.Itoa(i), i)
+	}
+
+	var buf bytes.Buffer
+	assert.NoError(suite.T(), gob.NewEncoder(&buf).Encode(tree))
+
+	loaded := Make[string, int]()
+	assert.NoError(suite.T(), gob.NewDecoder(&buf).Decode(loaded))
+	assert.NoError(suite.T(), loaded.Validate())
+	assert.Equal(suite.T(), tree.Keys(), loaded.Keys())
+}
+
+func (suite *BinarySuite) TestCorrupted() {
+	tree := Make[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	data, _ := tree.MarshalBinary()
+	loaded := Make[int, int]()
+
+	corrupted := append([]byte{}, data...)
+	corrupted[10] ^= 0xff
+	assert.Error(suite.T(), loaded.UnmarshalBinary(corrupted))
+
+	corrupted = append([]byte{}, data...)
+	corrupted[len(corrupted)-1] ^= 0xff
+	assert.ErrorIs(suite.T(), loaded.UnmarshalBinary(corrupted), ErrChecksum)
+
+	for i := 0; i < len(data); i++ {
+		assert.Error(suite.T(), loaded.UnmarshalBinary(data[:i]))
+	}
+	assert.ErrorIs(suite.T(), loaded.UnmarshalBinary(append(data, 0)), ErrInvalidFormat)
+
+	assert.ErrorIs(suite.T(), loaded.UnmarshalBinary([]byte("XYZ\x01\x00")), ErrInvalidFormat)
+	assert.ErrorIs(suite.T(), loaded.UnmarshalBinary(withChecksum([]byte("RBT\x02\x00"))), ErrUnsupportedVersion)
+	assert.ErrorIs(suite.T(), loaded.UnmarshalBinary(withChecksum([]byte("RBT\x01\xff\xff\xff\xff\x0f"))), ErrInvalidFormat)
+
+	// Entries 2:0 and 1:0 are out of order
+	assert.ErrorIs(suite.T(), loaded.UnmarshalBinary(withChecksum([]byte("RBT\x01\x02\x04\x00\x02\x00"))), ErrInvalidFormat)
+	assert.NoError(suite.T(), loaded.UnmarshalBinary(withChecksum([]byte("RBT\x01\x02\x02\x00\x04\x00"))))
+	assert.Equal(suite.T(), []int{1, 2}, loaded.Keys())
+
+	assert.Equal(suite.T(), 2, loaded.Size())
+}
+
+func TestBinarySuite(t *testing.T) {
+	suite.Run(t, new(BinarySuite))
+}
@@ -0,0 +1,194 @@
+package rbt
+
+import (
+	"container/list"
+
+	"golang.org/x/exp/constraints"
+)
+
+// EvictionPolicy selects an entry to be evicted from a full BoundedTree
+type EvictionPolicy uint8
+
+const (
+	EvictSmallest EvictionPolicy = iota // Entry with the smallest key
+	EvictLargest                        // Entry with the largest key
+	EvictLRU                            // Least recently inserted, updated or found entry
+	EvictLFU                            // Least frequently used entry, least recently used among equally used
+)
+
+func (p EvictionPolicy) String() string {
+	switch p {
+	case EvictSmallest:
+		return "SMALLEST"
+	case EvictLargest:
+		return "LARGEST"
+	case EvictLRU:
+		return "LRU"
+	case EvictLFU:
+		return "LFU"
+	default:
+		panic("invalid eviction policy")
+	}
+}
+
+// Usage of an entry, tracked for LRU and LFU policies
+type usage[K any] struct {
+	key   K
+	count int
+}
+
+/*
+BoundedTree is a tree, which size never exceeds its capacity.
+Inserting a new key into a full tree evicts an entry chosen by a policy.
+With EvictSmallest or EvictLargest a new key is inserted first, so it may be evicted right away,
+which makes a tree a top-N buffer. With EvictLRU or EvictLFU an entry is evicted before inserting a new key.
+*/
+type BoundedTree[K constraints.Ordered, V any] struct {
+	tree     *RedBlackTree[K, V]
+	capacity int
+	policy   EvictionPolicy
+
+	// Usage lists, ordered from the most to the least recently used.
+	// LRU keeps a single list, LFU keeps a list per usage count.
+	usages  map[K]*list.Element
+	lists   map[int]*list.List
+	minUsed int // Lower bound of usage counts, exact if it has a list
+
+	// Optional callback, called for every evicted entry
+	OnEvict func(k K, v V)
+}
+
+// Function MakeBounded creates empty instance of a bounded tree. It panics if capacity is not positive
+func MakeBounded[K constraints.Ordered, V any](capacity int, policy EvictionPolicy) *BoundedTree[K, V] {
+	if capacity < 1 {
+		panic("capacity must be positive")
+	}
+
+	return &BoundedTree[K, V]{
+		tree:     Make[K, V](),
+		capacity: capacity,
+		policy:   policy,
+		usages:   map[K]*list.Element{},
+		lists:    map[int]*list.List{},
+	}
+}
+
+func (bt *BoundedTree[K, V]) tracksUsage() bool {
+	return bt.policy == EvictLRU || bt.policy == EvictLFU
+}
+
+// Function touch records a use of an entry, adding it to usage lists if needed
+func (bt *BoundedTree[K, V]) touch(k K) {
+	if !bt.tracksUsage() {
+		return
+	}
+
+	u := usage[K]{key: k}
+	if e, ok := bt.usages[k]; ok {
+		u = bt.untrack(e)
+	}
+	if bt.policy == EvictLFU {
+		u.count++
+	}
+
+	l := bt.lists[u.count]
+	if l == nil {
+		l = list.New()
+		bt.lists[u.count] = l
+	}
+	bt.usages[k] = l.PushFront(u)
+	if len(bt.usages) == 1 || u.count < bt.minUsed {
+		bt.minUsed = u.count
+	}
+}
+
+func (bt *BoundedTree[K, V]) untrack(e *list.Element) usage[K] {
+	u := e.Value.(usage[K])
+	l := bt.lists[u.count]
+	l.Remove(e)
+	delete(bt.usages, u.key)
+
+	if l.Len() == 0 {
+		delete(bt.lists, u.count)
+	}
+	return u
+}
+
+func (bt *BoundedTree[K, V]) evict() {
+	var n *Node[K, V]
+	switch bt.policy {
+	case EvictSmallest:
+		n = bt.tree.root.minimum()
+	case EvictLargest:
+		n = bt.tree.root.maximum()
+	default:
+		if bt.lists[bt.minUsed] == nil {
+			first := true
+			for count := range bt.lists {
+				if first || count < bt.minUsed {
+					bt.minUsed = count
+					first = false
+				}
+			}
+		}
+		u := bt.lists[bt.minUsed].Back().Value.(usage[K])
+		n = bt.tree.search(u.key)
+	}
+
+	k, v := n.key, n.value
+	bt.Remove(k)
+	if bt.OnEvict != nil {
+		bt.OnEvict(k, v)
+	}
+}
+
+// Function Insert puts a value into a tree, evicting an entry if a tree is full. If key already exists - function updates it's value
+func (bt *BoundedTree[K, V]) Insert(k K, v V) {
+	exists := bt.tree.search(k) != nil
+	if !exists && bt.tracksUsage() && bt.tree.Size() == bt.capacity {
+		bt.evict()
+	}
+
+	bt.tree.Insert(k, v)
+	bt.touch(k)
+
+	for bt.tree.Size() > bt.capacity {
+		bt.evict()
+	}
+}
+
+// Function Search performs lookup of a value by key, which counts as a use of an entry
+func (bt *BoundedTree[K, V]) Search(k K) (value V, exists bool) {
+	if value, exists = bt.tree.Search(k); exists {
+		bt.touch(k)
+	}
+	return
+}
+
+// Function Remove removes given key from a tree
+func (bt *BoundedTree[K, V]) Remove(k K) {
+	if e, ok := bt.usages[k]; ok {
+		bt.untrack(e)
+	}
+	bt.tree.Remove(k)
+}
+
+// Function Keys returns a collection of keys in ascending order
+func (bt *BoundedTree[K, V]) Keys() []K {
+	return bt.tree.Keys()
+}
+
+// Function Traverse applies a closure to every entry in ascending key order, which does not count as a use
+func (bt *BoundedTree[K, V]) Traverse(closure func(k K, v V)) {
+	bt.tree.Traverse(closure)
+}
+
+// Function Size returns a number of elements stored in a tree
+func (bt *BoundedTree[K, V]) Size() int {
+	return bt.tree.Size()
+}
+
+// Function Capacity returns a maximum number of elements stored in a tree
+func (bt *BoundedTree[K, V]) Capacity() int {
+	return bt.capacity
+}
@@ -0,0 +1,108 @@
+package rbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type BoundedTreeSuite struct {
+	suite.Suite
+}
+
+func (suite *BoundedTreeSuite) TestTopN() {
+	tree := MakeBounded[int, string](3, EvictSmallest)
+	evicted := []int{}
+	tree.OnEvict = func(k int, v string) { evicted = append(evicted, k) }
+
+	for _, k := range []int{5, 1, 9, 7, 3, 8} {
+		tree.Insert(k, "")
+		assert.LessOrEqual(suite.T(), tree.Size(), tree.Capacity())
+	}
+
+	assert.Equal(suite.T(), []int{7, 8, 9}, tree.Keys())
+	// A key smaller than all kept ones is evicted right away
+	assert.Equal(suite.T(), []int{1, 3, 5}, evicted)
+}
+
+func (suite *BoundedTreeSuite) TestEvictLargest() {
+	tree := MakeBounded[int, string](2, EvictLargest)
+	for _, k := range []int{5, 1, 9, 0} {
+		tree.Insert(k, "")
+	}
+	assert.Equal(suite.T(), []int{0, 1}, tree.Keys())
+
+	// Update does not evict
+	tree.Insert(1, "updated")
+	v, _ := tree.Search(1)
+	assert.Equal(suite.T(), "updated", v)
+	assert.Equal(suite.T(), []int{0, 1}, tree.Keys())
+}
+
+func (suite *BoundedTreeSuite) TestLRU() {
+	tree := MakeBounded[string, int](3, EvictLRU)
+	evicted := []string{}
+	tree.OnEvict = func(k string, v int) { evicted = append(evicted, k) }
+
+	tree.Insert("a", 1)
+	tree.Insert("b", 2)
+	tree.Insert("c", 3)
+	tree.Search("a")
+	tree.Insert("d", 4) // Evicts b
+	tree.Insert("c", 30)
+	tree.Insert("e", 5) // Evicts a
+
+	assert.Equal(suite.T(), []string{"b", "a"}, evicted)
+	assert.Equal(suite.T(), []string{"c", "d", "e"}, tree.Keys())
+
+	tree.Remove("d")
+	tree.Insert("f", 6)
+	assert.Equal(suite.T(), []string{"b", "a"}, evicted)
+	tree.Insert("g", 7) // Evicts c
+	assert.Equal(suite.T(), []string{"b", "a", "c"}, evicted)
+	assert.Len(suite.T(), tree.usages, 3)
+}
+
+func (suite *BoundedTreeSuite) TestLFU() {
+	tree := MakeBounded[string, int](3, EvictLFU)
+	evicted := []string{}
+	tree.OnEvict = func(k string, v int) { evicted = append(evicted, k) }
+
+	tree.Insert("a", 1)
+	tree.Insert("b", 2)
+	tree.Insert("c", 3)
+	for i := 0; i < 3; i++ {
+		tree.Search("a")
+	}
+	tree.Search("b")
+	tree.Insert("d", 4) // c is used once
+	tree.Insert("e", 5) // d and b are used once and twice
+	assert.Equal(suite.T(), []string{"c", "d"}, evicted)
+
+	tree.Search("e")
+	tree.Search("e")
+	// b and e are used twice, b less recently
+	tree.Insert("f", 6)
+	assert.Equal(suite.T(), []string{"c", "d", "b"}, evicted)
+	assert.Equal(suite.T(), []string{"a", "e", "f"}, tree.Keys())
+
+	// Removal of the least used entry leaves a stale minimum, which is recomputed
+	tree.Remove("f")
+	tree.Insert("g", 7)
+	tree.Search("g")
+	tree.Search("g")
+	tree.Search("g")
+	tree.Search("g")
+	tree.Insert("h", 8)
+	assert.Equal(suite.T(), []string{"c", "d", "b", "e"}, evicted)
+	assert.Len(suite.T(), tree.usages, 3)
+}
+
+func (suite *BoundedTreeSuite) TestInvalidCapacity() {
+	assert.Panics(suite.T(), func() { MakeBounded[int, int](0, EvictLRU) })
+}
+
+func TestBoundedTreeSuite(t *testing.T) {
+	suite.Run(t, new(BoundedTreeSuite))
+}
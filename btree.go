@@ -0,0 +1,377 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+	"golang.org/x/exp/slices"
+)
+
+/*
+Default minimum degree of a B-tree. Every node but the root holds from degree-1 to 2*degree-1 keys.
+Nodes of the default degree span a few cache lines for small keys, while staying cheap to shift on insertion.
+*/
+const DefaultBTreeDegree = 32
+
+type btreeNode[K constraints.Ordered, V any] struct {
+	keys     []K
+	values   []V
+	children []*btreeNode[K, V] // Empty for a leaf, otherwise one more than keys
+}
+
+func (node *btreeNode[K, V]) isLeaf() bool {
+	return len(node.children) == 0
+}
+
+// Function find returns an index of the first key which is not less than k, and whether it is equal to k
+func (node *btreeNode[K, V]) find(k K) (int, bool) {
+	return slices.BinarySearch(node.keys, k)
+}
+
+// Function removeAt removes an element of a slice, zeroing the freed slot so it holds no references
+func removeAt[T any](s []T, i int) []T {
+	copy(s[i:], s[i+1:])
+	var zero T
+	s[len(s)-1] = zero
+	return s[:len(s)-1]
+}
+
+// Function splitChild splits a full child in two, moving its median key into the node
+func (node *btreeNode[K, V]) splitChild(i, degree int) {
+	child := node.children[i]
+	sibling := &btreeNode[K, V]{
+		keys:   append(make([]K, 0, 2*degree-1), child.keys[degree:]...),
+		values: append(make([]V, 0, 2*degree-1), child.values[degree:]...),
+	}
+	if !child.isLeaf() {
+		sibling.children = append(make([]*btreeNode[K, V], 0, 2*degree), child.children[degree:]...)
+	}
+
+	node.keys = slices.Insert(node.keys, i, child.keys[degree-1])
+	node.values = slices.Insert(node.values, i, child.values[degree-1])
+	node.children = slices.Insert(node.children, i+1, sibling)
+
+	// Moved slots are zeroed, so that they do not hold references
+	var zeroK K
+	var zeroV V
+	for j := degree - 1; j < len(child.keys); j++ {
+		child.keys[j], child.values[j] = zeroK, zeroV
+	}
+	child.keys, child.values = child.keys[:degree-1], child.values[:degree-1]
+	if !child.isLeaf() {
+		for j := degree; j < len(child.children); j++ {
+			child.children[j] = nil
+		}
+		child.children = child.children[:degree]
+	}
+}
+
+// Function merge merges children i and i+1 together with the key between them
+func (node *btreeNode[K, V]) merge(i int) {
+	left, right := node.children[i], node.children[i+1]
+
+	left.keys = append(append(left.keys, node.keys[i]), right.keys...)
+	left.values = append(append(left.values, node.values[i]), right.values...)
+	left.children = append(left.children, right.children...)
+
+	node.keys = removeAt(node.keys, i)
+	node.values = removeAt(node.values, i)
+	node.children = removeAt(node.children, i+1)
+}
+
+// Function rotateRight moves the last key of child i-1 through the node into child i
+func (node *btreeNode[K, V]) rotateRight(i int) {
+	left, child := node.children[i-1], node.children[i]
+
+	child.keys = slices.Insert(child.keys, 0, node.keys[i-1])
+	child.values = slices.Insert(child.values, 0, node.values[i-1])
+	last := len(left.keys) - 1
+	node.keys[i-1], node.values[i-1] = left.keys[last], left.values[last]
+	left.keys, left.values = removeAt(left.keys, last), removeAt(left.values, last)
+
+	if !left.isLeaf() {
+		last := len(left.children) - 1
+		child.children = slices.Insert(child.children, 0, left.children[last])
+		left.children = removeAt(left.children, last)
+	}
+}
+
+// Function rotateLeft moves the first key of child i+1 through the node into child i
+func (node *btreeNode[K, V]) rotateLeft(i int) {
+	child, right := node.children[i], node.children[i+1]
+
+	child.keys = append(child.keys, node.keys[i])
+	child.values = append(child.values, node.values[i])
+	node.keys[i], node.values[i] = right.keys[0], right.values[0]
+	right.keys, right.values = removeAt(right.keys, 0), removeAt(right.values, 0)
+
+	if !right.isLeaf() {
+		child.children = append(child.children, right.children[0])
+		right.children = removeAt(right.children, 0)
+	}
+}
+
+/*
+Function remove removes a key from a subtree in a single pass down, as described in Introduction to Algorithms:
+before descending into a child it makes sure the child holds at least degree keys,
+so removal from a leaf never leaves it underfull. Returns whether a key was found.
+*/
+func (node *btreeNode[K, V]) remove(k K, degree int) bool {
+	for {
+		i, found := node.find(k)
+		if node.isLeaf() {
+			if found {
+				node.keys, node.values = removeAt(node.keys, i), removeAt(node.values, i)
+			}
+			return found
+		}
+
+		if found {
+			// Key of an inner node is replaced by its predecessor or successor, which is removed from a leaf
+			switch left, right := node.children[i], node.children[i+1]; {
+			case len(left.keys) >= degree:
+				last := left
+				for !last.isLeaf() {
+					last = last.children[len(last.children)-1]
+				}
+				j := len(last.keys) - 1
+				node.keys[i], node.values[i] = last.keys[j], last.values[j]
+				k, node = last.keys[j], left
+			case len(right.keys) >= degree:
+				first := right
+				for !first.isLeaf() {
+					first = first.children[0]
+				}
+				node.keys[i], node.values[i] = first.keys[0], first.values[0]
+				k, node = first.keys[0], right
+			default:
+				node.merge(i)
+				node = left
+			}
+			continue
+		}
+
+		if len(node.children[i].keys) < degree {
+			switch {
+			case i > 0 && len(node.children[i-1].keys) >= degree:
+				node.rotateRight(i)
+			case i < len(node.keys) && len(node.children[i+1].keys) >= degree:
+				node.rotateLeft(i)
+			case i < len(node.keys):
+				node.merge(i)
+			default:
+				node.merge(i - 1)
+				i--
+			}
+		}
+		node = node.children[i]
+	}
+}
+
+func (node *btreeNode[K, V]) traverse(closure func(k K, v V)) {
+	for i := range node.keys {
+		if !node.isLeaf() {
+			node.children[i].traverse(closure)
+		}
+		closure(node.keys[i], node.values[i])
+	}
+	if !node.isLeaf() {
+		node.children[len(node.keys)].traverse(closure)
+	}
+}
+
+/*
+BTree is an OrderedMap backed by an in-memory B-tree.
+Every node keeps many keys in a contiguous slice, so lookups touch few nodes
+and scan memory sequentially, which suits caches better than pointer-linked binary trees.
+*/
+type BTree[K constraints.Ordered, V any] struct {
+	root   *btreeNode[K, V]
+	degree int
+	size   int
+}
+
+// Function MakeBTree creates empty instance of a B-tree of DefaultBTreeDegree
+func MakeBTree[K constraints.Ordered, V any]() *BTree[K, V] {
+	return MakeBTreeWithDegree[K, V](DefaultBTreeDegree)
+}
+
+/*
+Function MakeBTreeWithDegree creates empty instance of a B-tree of a given minimum degree, which is at least 2.
+Larger degree makes a tree shallower and its nodes wider: lookups touch fewer nodes,
+while insertions and removals shift more keys within a node.
+*/
+func MakeBTreeWithDegree[K constraints.Ordered, V any](degree int) *BTree[K, V] {
+	if degree < 2 {
+		panic("degree must be at least 2")
+	}
+	return &BTree[K, V]{degree: degree}
+}
+
+// Function Degree returns a minimum degree of a tree
+func (tree *BTree[K, V]) Degree() int {
+	return tree.degree
+}
+
+func (tree *BTree[K, V]) makeNode() *btreeNode[K, V] {
+	return &btreeNode[K, V]{
+		keys:   make([]K, 0, 2*tree.degree-1),
+		values: make([]V, 0, 2*tree.degree-1),
+	}
+}
+
+/*
+Function Insert puts a value into a tree. If key already exists - function updates it's value.
+Full nodes are split on the way down, so an insertion takes a single pass.
+*/
+func (tree *BTree[K, V]) Insert(k K, v V) {
+	if tree.root == nil {
+		tree.root = tree.makeNode()
+	}
+	if len(tree.root.keys) == 2*tree.degree-1 {
+		root := tree.makeNode()
+		root.children = append(make([]*btreeNode[K, V], 0, 2*tree.degree), tree.root)
+		root.splitChild(0, tree.degree)
+		tree.root = root
+	}
+
+	for node := tree.root; ; {
+		i, found := node.find(k)
+		if found {
+			node.values[i] = v
+			return
+		}
+		if node.isLeaf() {
+			node.keys = slices.Insert(node.keys, i, k)
+			node.values = slices.Insert(node.values, i, v)
+			tree.size++
+			return
+		}
+
+		if len(node.children[i].keys) == 2*tree.degree-1 {
+			node.splitChild(i, tree.degree)
+			switch {
+			case k == node.keys[i]:
+				node.values[i] = v
+				return
+			case k > node.keys[i]:
+				i++
+			}
+		}
+		node = node.children[i]
+	}
+}
+
+// Function Search performs lookup of a value by key. Returns whether key was found
+func (tree *BTree[K, V]) Search(k K) (value V, exists bool) {
+	for node := tree.root; node != nil; {
+		i, found := node.find(k)
+		if found {
+			return node.values[i], true
+		}
+		if node.isLeaf() {
+			break
+		}
+		node = node.children[i]
+	}
+	return
+}
+
+// Function Remove removes given key from a tree
+func (tree *BTree[K, V]) Remove(k K) {
+	if tree.root == nil {
+		return
+	}
+	if tree.root.remove(k, tree.degree) {
+		tree.size--
+	}
+
+	// Root loses its last key when its only two children are merged
+	if len(tree.root.keys) == 0 {
+		if tree.root.isLeaf() {
+			tree.root = nil
+		} else {
+			tree.root = tree.root.children[0]
+		}
+	}
+}
+
+// Function Keys returns a collection of keys in ascending order
+func (tree *BTree[K, V]) Keys() []K {
+	result := make([]K, 0, tree.size)
+	tree.Traverse(func(k K, v V) {
+		result = append(result, k)
+	})
+	return result
+}
+
+// Function Traverse applies a closure to every entry in ascending key order
+func (tree *BTree[K, V]) Traverse(closure func(k K, v V)) {
+	if tree.root != nil {
+		tree.root.traverse(closure)
+	}
+}
+
+// Function Size returns a number of elements stored in a tree
+func (tree *BTree[K, V]) Size() int {
+	return tree.size
+}
+
+// Function Min returns an entry with the smallest key. Returns false if tree is empty
+func (tree *BTree[K, V]) Min() (k K, v V, found bool) {
+	node := tree.root
+	if node == nil {
+		return
+	}
+	for !node.isLeaf() {
+		node = node.children[0]
+	}
+	return node.keys[0], node.values[0], true
+}
+
+// Function Max returns an entry with the largest key. Returns false if tree is empty
+func (tree *BTree[K, V]) Max() (k K, v V, found bool) {
+	node := tree.root
+	if node == nil {
+		return
+	}
+	for !node.isLeaf() {
+		node = node.children[len(node.children)-1]
+	}
+	last := len(node.keys) - 1
+	return node.keys[last], node.values[last], true
+}
+
+// Function Floor returns an entry with the largest key less than or equal to k. Returns false if there is none
+func (tree *BTree[K, V]) Floor(k K) (key K, value V, found bool) {
+	for node := tree.root; node != nil; {
+		i, exact := node.find(k)
+		if exact {
+			return node.keys[i], node.values[i], true
+		}
+		if i > 0 {
+			key, value, found = node.keys[i-1], node.values[i-1], true
+		}
+		if node.isLeaf() {
+			break
+		}
+		node = node.children[i]
+	}
+	return
+}
+
+// Function Ceiling returns an entry with the smallest key greater than or equal to k. Returns false if there is none
+func (tree *BTree[K, V]) Ceiling(k K) (key K, value V, found bool) {
+	for node := tree.root; node != nil; {
+		i, exact := node.find(k)
+		if exact {
+			return node.keys[i], node.values[i], true
+		}
+		if i < len(node.keys) {
+			key, value, found = node.keys[i], node.values[i], true
+		}
+		if node.isLeaf() {
+			break
+		}
+		node = node.children[i]
+	}
+	return
+}
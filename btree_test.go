@@ -0,0 +1,195 @@
+package rbt
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// Function check verifies order of keys and occupancy of a subtree, returning its height
+func (node *btreeNode[K, V]) check(minKey, maxKey *K, degree int, isRoot bool) (int, error) {
+	if len(node.keys) != len(node.values) {
+		return 0, fmt.Errorf("node has %d keys and %d values", len(node.keys), len(node.values))
+	}
+	if len(node.keys) > 2*degree-1 || (!isRoot && len(node.keys) < degree-1) || len(node.keys) == 0 {
+		return 0, fmt.Errorf("node holds %d keys", len(node.keys))
+	}
+	for i, k := range node.keys {
+		if (i > 0 && !(k > node.keys[i-1])) || (minKey != nil && !(k > *minKey)) || (maxKey != nil && !(k < *maxKey)) {
+			return 0, fmt.Errorf("key %v is out of order", k)
+		}
+	}
+	if node.isLeaf() {
+		return 1, nil
+	}
+	if len(node.children) != len(node.keys)+1 {
+		return 0, fmt.Errorf("node has %d keys and %d children", len(node.keys), len(node.children))
+	}
+
+	height := -1
+	for i, child := range node.children {
+		lo, hi := minKey, maxKey
+		if i > 0 {
+			lo = &node.keys[i-1]
+		}
+		if i < len(node.keys) {
+			hi = &node.keys[i]
+		}
+
+		h, err := child.check(lo, hi, degree, false)
+		if err != nil {
+			return 0, err
+		}
+		if height >= 0 && h != height {
+			return 0, fmt.Errorf("leaves are at different depths")
+		}
+		height = h
+	}
+	return height + 1, nil
+}
+
+func (tree *BTree[K, V]) check() error {
+	if tree.root == nil {
+		return nil
+	}
+	_, err := tree.root.check(nil, nil, tree.degree, true)
+	return err
+}
+
+func TestOrderedMapBTree(t *testing.T) {
+	runOrderedMapSuite(t,
+		func() OrderedMap[int, int] { return MakeBTree[int, int]() },
+		func(m OrderedMap[int, int]) error { return m.(*BTree[int, int]).check() })
+}
+
+func TestOrderedMapSmallBTree(t *testing.T) {
+	// Minimal degree makes every operation split, merge and rotate nodes
+	runOrderedMapSuite(t,
+		func() OrderedMap[int, int] { return MakeBTreeWithDegree[int, int](2) },
+		func(m OrderedMap[int, int]) error { return m.(*BTree[int, int]).check() })
+}
+
+func TestBTreeDegree(t *testing.T) {
+	assert.Equal(t, DefaultBTreeDegree, MakeBTree[int, int]().Degree())
+	assert.Equal(t, 3, MakeBTreeWithDegree[int, int](3).Degree())
+	assert.Panics(t, func() { MakeBTreeWithDegree[int, int](1) })
+
+	// Wider nodes make a tree shallower
+	heights := map[int]int{}
+	for _, degree := range []int{2, 8, 64} {
+		tree := MakeBTreeWithDegree[int, int](degree)
+		for i := 0; i < 10000; i++ {
+			tree.Insert(i, i)
+		}
+		height, err := tree.root.check(nil, nil, degree, true)
+		assert.NoError(t, err)
+		heights[degree] = height
+	}
+	assert.Greater(t, heights[2], heights[8])
+	assert.Greater(t, heights[8], heights[64])
+}
+
+func makeFilledBTree(degree, x, y int) *BTree[int, int] {
+	tree := MakeBTreeWithDegree[int, int](degree)
+
+	for i := 0; i < x; i++ {
+		for j := 0; j < y; j++ {
+			tree.Insert(i*j+j, 0)
+		}
+	}
+
+	return tree
+}
+
+func BenchmarkBTreeInsert(b *testing.B) {
+	for i := iMin; i <= iMax; i++ {
+		n := 1 << i
+		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			tree := MakeBTree[int, int]()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StartTimer()
+				for j := 0; j < n; j++ {
+					tree.Insert(i*j+j, 0)
+				}
+				b.StopTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkBTreeSearch(b *testing.B) {
+	for i := iMin; i <= iMax; i++ {
+		n := 1 << i
+		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			tree := makeFilledBTree(DefaultBTreeDegree, b.N, n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StartTimer()
+				for j := 0; j < n; j++ {
+					tree.Search(i*j + j)
+				}
+				b.StopTimer()
+			}
+		})
+	}
+}
+
+func BenchmarkBTreeDelete(b *testing.B) {
+	for i := iMin; i <= iMax; i++ {
+		n := 1 << i
+		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			tree := makeFilledBTree(DefaultBTreeDegree, b.N, n)
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				b.StartTimer()
+				for j := 0; j < n; j++ {
+					tree.Remove(i*j + j)
+				}
+				b.StopTimer()
+			}
+		})
+	}
+}
+
+/*
+BenchmarkLargeSearch compares lookups of random keys in large trees, where nodes no longer fit into caches.
+Run with -bench LargeSearch to pick a backend and a degree for a dataset.
+*/
+func BenchmarkLargeSearch(b *testing.B) {
+	const n = 1 << 20
+	keys := rand.New(rand.NewSource(1)).Perm(n)
+
+	maps := []struct {
+		name string
+		make func() OrderedMap[int, int]
+	}{
+		{"rbt", func() OrderedMap[int, int] { return Make[int, int]() }},
+		{"arena", func() OrderedMap[int, int] { return MakeArena[int, int]() }},
+		{"btree_degree_4", func() OrderedMap[int, int] { return MakeBTreeWithDegree[int, int](4) }},
+		{"btree_degree_16", func() OrderedMap[int, int] { return MakeBTreeWithDegree[int, int](16) }},
+		{"btree_degree_64", func() OrderedMap[int, int] { return MakeBTreeWithDegree[int, int](64) }},
+	}
+
+	for _, m := range maps {
+		b.Run(m.name, func(b *testing.B) {
+			tree := m.make()
+			for _, k := range keys {
+				tree.Insert(k, k)
+			}
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				tree.Search(keys[i%n])
+			}
+		})
+	}
+}
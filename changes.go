@@ -0,0 +1,271 @@
+package rbt
+
+import (
+	"bufio"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+
+	"golang.org/x/exp/constraints"
+)
+
+// ChangeOp is a kind of a mutation recorded in a change log
+type ChangeOp uint8
+
+const (
+	ChangeInsert ChangeOp = iota + 1
+	ChangeUpdate
+	ChangeRemove
+)
+
+func (op ChangeOp) String() string {
+	switch op {
+	case ChangeInsert:
+		return "INSERT"
+	case ChangeUpdate:
+		return "UPDATE"
+	case ChangeRemove:
+		return "REMOVE"
+	default:
+		panic("invalid change operation")
+	}
+}
+
+// ErrChangeGap is reported when changes are missing from a sequence
+var ErrChangeGap = errors.New("gap in change sequence")
+
+/*
+Change is a single mutation of a tree.
+Value is the inserted value, the new value of an update, or the removed value.
+Sequence numbers start with 1 and have no gaps.
+*/
+type Change[K any, V any] struct {
+	Seq   uint64
+	Op    ChangeOp
+	Key   K
+	Value V
+}
+
+/*
+ChangeLog records mutations of a tree as an ordered, sequence-numbered list of changes.
+The tree itself is not safe for concurrent use, but the log is: changes may be read
+by other goroutines while the tree is being mutated.
+*/
+type ChangeLog[K constraints.Ordered, V any] struct {
+	mu      sync.Mutex
+	changes []Change[K, V] // Retained changes, starting right after truncated ones
+	seq     uint64         // Sequence number of the last change
+	notify  chan struct{}  // Closed on every new change
+	cancel  func()
+}
+
+/*
+Function NewChangeLog starts recording changes of a tree.
+Entries which are already stored in a tree are recorded as initial inserts,
+so a replica, which applies the whole log to an empty tree, ends up equal to the source.
+*/
+func NewChangeLog[K constraints.Ordered, V any](tree *RedBlackTree[K, V]) *ChangeLog[K, V] {
+	l := &ChangeLog[K, V]{notify: make(chan struct{})}
+
+	tree.Traverse(func(k K, v V) {
+		l.append(ChangeInsert, k, v)
+	})
+	l.cancel = tree.Observe(Observer[K, V]{
+		Insert: func(k K, v V) { l.append(ChangeInsert, k, v) },
+		Update: func(k K, old, new V) { l.append(ChangeUpdate, k, new) },
+		Remove: func(k K, v V) { l.append(ChangeRemove, k, v) },
+	})
+	return l
+}
+
+func (l *ChangeLog[K, V]) append(op ChangeOp, k K, v V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.seq++
+	l.changes = append(l.changes, Change[K, V]{Seq: l.seq, Op: op, Key: k, Value: v})
+	close(l.notify)
+	l.notify = make(chan struct{})
+}
+
+// Function Seq returns a sequence number of the last recorded change
+func (l *ChangeLog[K, V]) Seq() uint64 {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.seq
+}
+
+/*
+Function Since returns all changes following a given sequence number.
+It reports ErrChangeGap if some of them are already truncated.
+*/
+func (l *ChangeLog[K, V]) Since(seq uint64) ([]Change[K, V], error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	first := l.seq - uint64(len(l.changes)) + 1
+	if seq+1 < first {
+		return nil, fmt.Errorf("%w: changes since %d are requested, but the log starts at %d", ErrChangeGap, seq, first)
+	}
+	if seq >= l.seq {
+		return nil, nil
+	}
+
+	changes := l.changes[seq+1-first:]
+	return append([]Change[K, V](nil), changes...), nil
+}
+
+// Function Truncate discards changes up to and including a given sequence number
+func (l *ChangeLog[K, V]) Truncate(seq uint64) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	first := l.seq - uint64(len(l.changes)) + 1
+	if seq < first {
+		return
+	}
+	if seq >= l.seq {
+		l.changes = nil
+		return
+	}
+	l.changes = append([]Change[K, V](nil), l.changes[seq+1-first:]...)
+}
+
+/*
+Function Wait returns a channel, which is closed when a new change is recorded.
+It allows followers to wait for changes: Seq or Since have to be checked after obtaining the channel.
+*/
+func (l *ChangeLog[K, V]) Wait() <-chan struct{} {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	return l.notify
+}
+
+// Function Close stops recording changes. Must be called from the goroutine which mutates the tree
+func (l *ChangeLog[K, V]) Close() {
+	l.cancel()
+}
+
+// Replica keeps a tree in sync with a source tree by applying its changes in order
+type Replica[K constraints.Ordered, V any] struct {
+	tree *RedBlackTree[K, V]
+	seq  uint64
+}
+
+// Function NewReplica creates a replica, which applies changes to a given tree, starting with sequence number 1
+func NewReplica[K constraints.Ordered, V any](tree *RedBlackTree[K, V]) *Replica[K, V] {
+	return &Replica[K, V]{tree: tree}
+}
+
+// Function Tree returns a tree maintained by a replica
+func (r *Replica[K, V]) Tree() *RedBlackTree[K, V] {
+	return r.tree
+}
+
+// Function Seq returns a sequence number of the last applied change
+func (r *Replica[K, V]) Seq() uint64 {
+	return r.seq
+}
+
+/*
+Function ApplyChanges applies changes in order.
+Changes which are already applied are skipped, so delivery may be repeated.
+If a change does not directly follow the last applied one, it stops with ErrChangeGap,
+keeping all changes applied before the gap.
+*/
+func (r *Replica[K, V]) ApplyChanges(changes ...Change[K, V]) error {
+	for _, c := range changes {
+		if c.Seq <= r.seq {
+			continue
+		}
+		if c.Seq != r.seq+1 {
+			return fmt.Errorf("%w: expected change %d, got %d", ErrChangeGap, r.seq+1, c.Seq)
+		}
+
+		switch c.Op {
+		case ChangeInsert, ChangeUpdate:
+			r.tree.Insert(c.Key, c.Value)
+		case ChangeRemove:
+			r.tree.Remove(c.Key)
+		default:
+			return fmt.Errorf("invalid change operation %d", c.Op)
+		}
+		r.seq = c.Seq
+	}
+	return nil
+}
+
+// ChangeEncoder writes changes into a stream, e.g. a pipe to a replica
+type ChangeEncoder[K any, V any] struct {
+	w          io.Writer
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+}
+
+// Function NewChangeEncoder creates an encoder which writes into w using default codecs
+func NewChangeEncoder[K any, V any](w io.Writer) *ChangeEncoder[K, V] {
+	return &ChangeEncoder[K, V]{w: w, keyCodec: DefaultCodec[K](), valueCodec: DefaultCodec[V]()}
+}
+
+// Function SetCodecs sets codecs used to encode keys and values. Nil codec resets to DefaultCodec
+func (e *ChangeEncoder[K, V]) SetCodecs(keyCodec Codec[K], valueCodec Codec[V]) {
+	e.keyCodec, e.valueCodec = withDefaultCodecs(keyCodec, valueCodec)
+}
+
+// Function Encode writes changes, each one as a sequence number, an operation, a key and a value
+func (e *ChangeEncoder[K, V]) Encode(changes ...Change[K, V]) error {
+	bw := bufio.NewWriter(e.w)
+	for _, c := range changes {
+		bw.Write(binary.AppendUvarint(nil, c.Seq))
+		bw.WriteByte(byte(c.Op))
+		if err := e.keyCodec.Encode(bw, c.Key); err != nil {
+			return err
+		}
+		if err := e.valueCodec.Encode(bw, c.Value); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// ChangeDecoder reads changes written by ChangeEncoder
+type ChangeDecoder[K any, V any] struct {
+	r          *bufio.Reader
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+}
+
+// Function NewChangeDecoder creates a decoder which reads from r using default codecs
+func NewChangeDecoder[K any, V any](r io.Reader) *ChangeDecoder[K, V] {
+	return &ChangeDecoder[K, V]{r: bufio.NewReader(r), keyCodec: DefaultCodec[K](), valueCodec: DefaultCodec[V]()}
+}
+
+// Function SetCodecs sets codecs used to decode keys and values. Nil codec resets to DefaultCodec
+func (d *ChangeDecoder[K, V]) SetCodecs(keyCodec Codec[K], valueCodec Codec[V]) {
+	d.keyCodec, d.valueCodec = withDefaultCodecs(keyCodec, valueCodec)
+}
+
+// Function Decode reads a single change. It returns io.EOF at the end of a stream
+func (d *ChangeDecoder[K, V]) Decode() (c Change[K, V], err error) {
+	if c.Seq, err = binary.ReadUvarint(d.r); err != nil {
+		return c, err
+	}
+
+	op, err := d.r.ReadByte()
+	if err != nil {
+		return c, noEOF(err)
+	}
+	c.Op = ChangeOp(op)
+
+	if c.Key, err = d.keyCodec.Decode(d.r); err != nil {
+		return c, noEOF(err)
+	}
+	if c.Value, err = d.valueCodec.Decode(d.r); err != nil {
+		return c, noEOF(err)
+	}
+	return c, nil
+}
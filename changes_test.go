@@ -0,0 +1,175 @@
+package rbt
+
+import (
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type ChangesSuite struct {
+	suite.Suite
+}
+
+func (suite *ChangesSuite) TestChangeLog() {
+	tree := Make[int, string]()
+	tree.Insert(1, "a")
+
+	log := NewChangeLog(tree)
+	tree.Insert(2, "b")
+	tree.Insert(1, "c")
+	tree.Remove(2)
+	tree.Remove(3)
+
+	changes, err := log.Since(0)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []Change[int, string]{
+		{Seq: 1, Op: ChangeInsert, Key: 1, Value: "a"},
+		{Seq: 2, Op: ChangeInsert, Key: 2, Value: "b"},
+		{Seq: 3, Op: ChangeUpdate, Key: 1, Value: "c"},
+		{Seq: 4, Op: ChangeRemove, Key: 2, Value: "b"},
+	}, changes)
+	assert.Equal(suite.T(), uint64(4), log.Seq())
+
+	changes, err = log.Since(3)
+	assert.NoError(suite.T(), err)
+	assert.Len(suite.T(), changes, 1)
+	changes, err = log.Since(4)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), changes)
+
+	log.Truncate(2)
+	_, err = log.Since(1)
+	assert.ErrorIs(suite.T(), err, ErrChangeGap)
+	changes, err = log.Since(2)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), uint64(3), changes[0].Seq)
+
+	log.Truncate(10)
+	changes, err = log.Since(4)
+	assert.NoError(suite.T(), err)
+	assert.Empty(suite.T(), changes)
+
+	log.Close()
+	tree.Insert(5, "e")
+	assert.Equal(suite.T(), uint64(4), log.Seq())
+}
+
+func (suite *ChangesSuite) TestReplica() {
+	source := Make[int, string]()
+	log := NewChangeLog(source)
+	replica := NewReplica(Make[int, string]())
+
+	for i := 0; i < 100; i++ {
+		source.Insert(i%30, strconv.Itoa(i))
+		if i%3 == 0 {
+			source.Remove(i % 7)
+		}
+	}
+
+	changes, err := log.Since(replica.Seq())
+	require.NoError(suite.T(), err)
+	// Apply in two batches, repeating an overlap
+	assert.NoError(suite.T(), replica.ApplyChanges(changes[:50]...))
+	assert.NoError(suite.T(), replica.ApplyChanges(changes[40:]...))
+
+	assert.Equal(suite.T(), log.Seq(), replica.Seq())
+	assert.Equal(suite.T(), source.Keys(), replica.Tree().Keys())
+	source.Traverse(func(k int, v string) {
+		actual, _ := replica.Tree().Search(k)
+		assert.Equal(suite.T(), v, actual)
+	})
+}
+
+func (suite *ChangesSuite) TestGap() {
+	replica := NewReplica(Make[int, string]())
+
+	err := replica.ApplyChanges(
+		Change[int, string]{Seq: 1, Op: ChangeInsert, Key: 1, Value: "a"},
+		Change[int, string]{Seq: 3, Op: ChangeInsert, Key: 3, Value: "c"},
+	)
+	assert.ErrorIs(suite.T(), err, ErrChangeGap)
+	assert.Equal(suite.T(), uint64(1), replica.Seq())
+	assert.Equal(suite.T(), []int{1}, replica.Tree().Keys())
+}
+
+func (suite *ChangesSuite) TestPipe() {
+	source := Make[string, int]()
+	log := NewChangeLog(source)
+
+	r, w := io.Pipe()
+	replica := NewReplica(Make[string, int]())
+	done := make(chan error)
+
+	go func() {
+		dec := NewChangeDecoder[string, int](r)
+		for {
+			c, err := dec.Decode()
+			if err == io.EOF {
+				done <- nil
+				return
+			}
+			if err == nil {
+				err = replica.ApplyChanges(c)
+			}
+			if err != nil {
+				done <- err
+				return
+			}
+		}
+	}()
+
+	enc := NewChangeEncoder[string, int](w)
+	sent := uint64(0)
+	for round := 0; round < 5; round++ {
+		for i := 0; i < 20; i++ {
+			source.Insert(strconv.Itoa(i*round), i)
+		}
+		source.Remove("0")
+
+		changes, err := log.Since(sent)
+		require.NoError(suite.T(), err)
+		require.NoError(suite.T(), enc.Encode(changes...))
+		sent = log.Seq()
+		log.Truncate(sent)
+	}
+	w.Close()
+
+	assert.NoError(suite.T(), <-done)
+	assert.Equal(suite.T(), sent, replica.Seq())
+	assert.Equal(suite.T(), source.Keys(), replica.Tree().Keys())
+}
+
+func (suite *ChangesSuite) TestWait() {
+	tree := Make[int, int]()
+	log := NewChangeLog(tree)
+
+	wait := log.Wait()
+	select {
+	case <-wait:
+		suite.Fail("no changes yet")
+	default:
+	}
+
+	tree.Insert(1, 1)
+	<-wait
+	assert.Equal(suite.T(), uint64(1), log.Seq())
+}
+
+func (suite *ChangesSuite) TestTruncatedStream() {
+	r, w := io.Pipe()
+	go func() {
+		w.Write([]byte{1, byte(ChangeInsert)})
+		w.Close()
+	}()
+
+	_, err := NewChangeDecoder[int, int](r).Decode()
+	assert.ErrorIs(suite.T(), err, io.ErrUnexpectedEOF)
+}
+
+func TestChangesSuite(t *testing.T) {
+	suite.Run(t, new(ChangesSuite))
+}
@@ -0,0 +1,136 @@
+package rbt
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/gob"
+	"fmt"
+	"io"
+	"math"
+	"reflect"
+)
+
+// CodecReader is a source of encoded data, which also allows to read single bytes for varints
+type CodecReader interface {
+	io.Reader
+	io.ByteReader
+}
+
+// Codec encodes and decodes values of a single type, used by tree serialization
+type Codec[T any] interface {
+	Encode(w io.Writer, v T) error
+	Decode(r CodecReader) (T, error)
+}
+
+/*
+Function DefaultCodec returns a codec, used by serialization when no codec is set.
+Booleans, integers, floats and strings, including named types based on them,
+are encoded in a compact binary form. Values of other types are encoded with encoding/gob.
+*/
+func DefaultCodec[T any]() Codec[T] {
+	return defaultCodec[T]{}
+}
+
+type defaultCodec[T any] struct{}
+
+func (defaultCodec[T]) Encode(w io.Writer, v T) error {
+	var buf []byte
+
+	rv := reflect.ValueOf(&v).Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		if rv.Bool() {
+			buf = append(buf, 1)
+		} else {
+			buf = append(buf, 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		buf = binary.AppendVarint(buf, rv.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		buf = binary.AppendUvarint(buf, rv.Uint())
+	case reflect.Float32, reflect.Float64:
+		buf = binary.BigEndian.AppendUint64(buf, math.Float64bits(rv.Float()))
+	case reflect.String:
+		buf = binary.AppendUvarint(buf, uint64(rv.Len()))
+		buf = append(buf, rv.String()...)
+	default:
+		var enc bytes.Buffer
+		if err := gob.NewEncoder(&enc).Encode(&v); err != nil {
+			return err
+		}
+		buf = binary.AppendUvarint(buf, uint64(enc.Len()))
+		buf = append(buf, enc.Bytes()...)
+	}
+
+	_, err := w.Write(buf)
+	return err
+}
+
+func (defaultCodec[T]) Decode(r CodecReader) (v T, err error) {
+	rv := reflect.ValueOf(&v).Elem()
+	switch rv.Kind() {
+	case reflect.Bool:
+		var b byte
+		if b, err = r.ReadByte(); err == nil {
+			rv.SetBool(b != 0)
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		var x int64
+		if x, err = binary.ReadVarint(r); err == nil {
+			if rv.OverflowInt(x) {
+				return v, fmt.Errorf("value %d overflows %v", x, rv.Type())
+			}
+			rv.SetInt(x)
+		}
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		var x uint64
+		if x, err = binary.ReadUvarint(r); err == nil {
+			if rv.OverflowUint(x) {
+				return v, fmt.Errorf("value %d overflows %v", x, rv.Type())
+			}
+			rv.SetUint(x)
+		}
+	case reflect.Float32, reflect.Float64:
+		var buf [8]byte
+		if _, err = io.ReadFull(r, buf[:]); err == nil {
+			rv.SetFloat(math.Float64frombits(binary.BigEndian.Uint64(buf[:])))
+		}
+	case reflect.String:
+		var buf bytes.Buffer
+		if buf, err = readChunk(r); err == nil {
+			rv.SetString(buf.String())
+		}
+	default:
+		var buf bytes.Buffer
+		if buf, err = readChunk(r); err == nil {
+			err = gob.NewDecoder(&buf).Decode(&v)
+		}
+	}
+
+	return v, noEOF(err)
+}
+
+/*
+Function readChunk reads a length-prefixed chunk of data.
+Buffer grows as data arrives, so a corrupted length can not cause a huge allocation.
+*/
+func readChunk(r CodecReader) (buf bytes.Buffer, err error) {
+	n, err := binary.ReadUvarint(r)
+	if err != nil {
+		return buf, err
+	}
+	if n > math.MaxInt64 {
+		return buf, fmt.Errorf("invalid chunk length %d", n)
+	}
+
+	_, err = io.CopyN(&buf, r, int64(n))
+	return buf, err
+}
+
+// Function noEOF converts EOF into ErrUnexpectedEOF, as EOF in the middle of a value means truncated data
+func noEOF(err error) error {
+	if err == io.EOF {
+		return io.ErrUnexpectedEOF
+	}
+	return err
+}
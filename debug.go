@@ -0,0 +1,6 @@
+//go:build !rbtdebug
+
+package rbt
+
+// Full validation after every mutation is enabled by building with the rbtdebug tag
+const debug = false
@@ -0,0 +1,36 @@
+//go:build rbtdebug
+
+package rbt
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DebugSuite struct {
+	suite.Suite
+}
+
+func (suite *DebugSuite) TestPanicsOnBrokenTree() {
+	tree := Make[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+
+	tree.root.left.parent = nil
+	assert.Panics(suite.T(), func() { tree.Insert(100, 0) })
+
+	tree = Make[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+
+	tree.size++
+	assert.Panics(suite.T(), func() { tree.Remove(5) })
+}
+
+func TestDebugSuite(t *testing.T) {
+	suite.Run(t, new(DebugSuite))
+}
@@ -0,0 +1,64 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// DiffKind is a kind of a difference between two trees
+type DiffKind uint8
+
+const (
+	DiffAdded DiffKind = iota + 1
+	DiffRemoved
+	DiffChanged
+)
+
+func (kind DiffKind) String() string {
+	switch kind {
+	case DiffAdded:
+		return "ADDED"
+	case DiffRemoved:
+		return "REMOVED"
+	case DiffChanged:
+		return "CHANGED"
+	default:
+		panic("invalid diff kind")
+	}
+}
+
+/*
+Difference is an entry, which differs between two trees.
+Old is a value in the first tree, unless entry is added; New is a value in the second tree, unless entry is removed.
+*/
+type Difference[K any, V any] struct {
+	Kind DiffKind
+	Key  K
+	Old  V
+	New  V
+}
+
+/*
+Function Diff walks two trees in ascending key order simultaneously in O(n+m)
+and applies a closure to every entry, which is added to b, removed from a, or has a value
+that is not equal according to a given function. Differences are reported in ascending key order.
+Trees must not be modified until Diff returns.
+*/
+func Diff[K constraints.Ordered, V any](a, b *RedBlackTree[K, V], equal func(x, y V) bool, closure func(d Difference[K, V])) {
+	na, nb := a.First(), b.First()
+
+	for na != nil || nb != nil {
+		switch {
+		case nb == nil || (na != nil && na.key < nb.key):
+			closure(Difference[K, V]{Kind: DiffRemoved, Key: na.key, Old: na.value})
+			na = na.Next()
+		case na == nil || nb.key < na.key:
+			closure(Difference[K, V]{Kind: DiffAdded, Key: nb.key, New: nb.value})
+			nb = nb.Next()
+		default:
+			if !equal(na.value, nb.value) {
+				closure(Difference[K, V]{Kind: DiffChanged, Key: na.key, Old: na.value, New: nb.value})
+			}
+			na, nb = na.Next(), nb.Next()
+		}
+	}
+}
@@ -0,0 +1,99 @@
+package rbt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DiffSuite struct {
+	suite.Suite
+}
+
+func makeDiffTree(entries map[int]string) *RedBlackTree[int, string] {
+	tree := Make[int, string]()
+	for k, v := range entries {
+		tree.Insert(k, v)
+	}
+	return tree
+}
+
+func diffStrings(a, b *RedBlackTree[int, string]) []Difference[int, string] {
+	var result []Difference[int, string]
+	Diff(a, b, func(x, y string) bool { return x == y }, func(d Difference[int, string]) {
+		result = append(result, d)
+	})
+	return result
+}
+
+func (suite *DiffSuite) TestDiff() {
+	a := makeDiffTree(map[int]string{1: "a", 2: "b", 3: "c", 5: "e", 9: "i"})
+	b := makeDiffTree(map[int]string{0: "z", 2: "b", 3: "C", 5: "e", 7: "g"})
+
+	assert.Equal(suite.T(), []Difference[int, string]{
+		{Kind: DiffAdded, Key: 0, New: "z"},
+		{Kind: DiffRemoved, Key: 1, Old: "a"},
+		{Kind: DiffChanged, Key: 3, Old: "c", New: "C"},
+		{Kind: DiffAdded, Key: 7, New: "g"},
+		{Kind: DiffRemoved, Key: 9, Old: "i"},
+	}, diffStrings(a, b))
+}
+
+func (suite *DiffSuite) TestEmpty() {
+	empty := Make[int, string]()
+	tree := makeDiffTree(map[int]string{1: "a", 2: "b"})
+
+	assert.Empty(suite.T(), diffStrings(empty, Make[int, string]()))
+	assert.Empty(suite.T(), diffStrings(tree, tree))
+
+	assert.Equal(suite.T(), []Difference[int, string]{
+		{Kind: DiffAdded, Key: 1, New: "a"},
+		{Kind: DiffAdded, Key: 2, New: "b"},
+	}, diffStrings(empty, tree))
+	assert.Equal(suite.T(), []Difference[int, string]{
+		{Kind: DiffRemoved, Key: 1, Old: "a"},
+		{Kind: DiffRemoved, Key: 2, Old: "b"},
+	}, diffStrings(tree, empty))
+}
+
+func (suite *DiffSuite) TestReconcile() {
+	rng := rand.New(rand.NewSource(1))
+	a, b := Make[int, int](), Make[int, int]()
+	for i := 0; i < 2000; i++ {
+		a.Insert(rng.Intn(1000), rng.Intn(3))
+		b.Insert(rng.Intn(1000), rng.Intn(3))
+	}
+
+	var diffs []Difference[int, int]
+	Diff(a, b, func(x, y int) bool { return x == y }, func(d Difference[int, int]) {
+		diffs = append(diffs, d)
+	})
+
+	// Applying differences to a makes it equal to b
+	for _, d := range diffs {
+		switch d.Kind {
+		case DiffAdded, DiffChanged:
+			a.Insert(d.Key, d.New)
+		case DiffRemoved:
+			a.Remove(d.Key)
+		}
+	}
+
+	count := 0
+	Diff(a, b, func(x, y int) bool { return x == y }, func(Difference[int, int]) { count++ })
+	assert.Equal(suite.T(), 0, count)
+	assert.Equal(suite.T(), b.Keys(), a.Keys())
+}
+
+func (suite *DiffSuite) TestKindString() {
+	assert.Equal(suite.T(), "ADDED", DiffAdded.String())
+	assert.Equal(suite.T(), "REMOVED", DiffRemoved.String())
+	assert.Equal(suite.T(), "CHANGED", DiffChanged.String())
+	assert.Panics(suite.T(), func() { _ = DiffKind(0).String() })
+}
+
+func TestDiffSuite(t *testing.T) {
+	suite.Run(t, new(DiffSuite))
+}
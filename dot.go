@@ -0,0 +1,83 @@
+package rbt
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// DOTOptions configure the output of WriteDOT
+type DOTOptions[K any, V any] struct {
+	// Draw nil leaves as small black boxes
+	NilLeaves bool
+	// Formats a node label. By default only the key is printed
+	Label func(k K, v V) string
+}
+
+/*
+Function WriteDOT writes a tree structure in Graphviz DOT format.
+Nodes are filled with their colors, edges are drawn from parents to children,
+left child is always placed to the left of the right one.
+Options may be nil, in which case defaults are used.
+*/
+func (tree *RedBlackTree[K, V]) WriteDOT(w io.Writer, opts *DOTOptions[K, V]) error {
+	if opts == nil {
+		opts = &DOTOptions[K, V]{}
+	}
+	label := opts.Label
+	if label == nil {
+		label = func(k K, v V) string { return fmt.Sprint(k) }
+	}
+
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph rbt {")
+	fmt.Fprintln(bw, "\tgraph [ordering=out];")
+	fmt.Fprintln(bw, "\tnode [style=filled, fontcolor=white];")
+
+	ids := 0
+	var nilLeaf func(parent int, hidden bool)
+	nilLeaf = func(parent int, hidden bool) {
+		id := ids
+		ids++
+		if hidden {
+			fmt.Fprintf(bw, "\tn%d [style=invis];\n", id)
+			fmt.Fprintf(bw, "\tn%d -> n%d [style=invis];\n", parent, id)
+		} else {
+			fmt.Fprintf(bw, "\tn%d [shape=box, label=\"\", width=0.2, height=0.2, fillcolor=black];\n", id)
+			fmt.Fprintf(bw, "\tn%d -> n%d;\n", parent, id)
+		}
+	}
+
+	var walk func(n *Node[K, V]) int
+	walk = func(n *Node[K, V]) int {
+		id := ids
+		ids++
+		fmt.Fprintf(bw, "\tn%d [label=%s, fillcolor=%s];\n", id, strconv.Quote(label(n.key, n.value)), dotColor(n.color))
+
+		// A hidden placeholder keeps a single child on its side
+		hidden := !opts.NilLeaves && (n.left == nil) != (n.right == nil)
+		for _, child := range [...]*Node[K, V]{n.left, n.right} {
+			if child != nil {
+				fmt.Fprintf(bw, "\tn%d -> n%d;\n", id, walk(child))
+			} else if opts.NilLeaves || hidden {
+				nilLeaf(id, hidden)
+			}
+		}
+		return id
+	}
+
+	if tree.root != nil {
+		walk(tree.root)
+	}
+
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+func dotColor(c Color) string {
+	if c == red {
+		return "red"
+	}
+	return "black"
+}
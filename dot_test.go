@@ -0,0 +1,92 @@
+package rbt
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type DOTSuite struct {
+	suite.Suite
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("write failed")
+}
+
+func (suite *DOTSuite) TestEmpty() {
+	var sb strings.Builder
+	assert.NoError(suite.T(), Make[int, int]().WriteDOT(&sb, nil))
+	assert.Equal(suite.T(), `digraph rbt {
+	graph [ordering=out];
+	node [style=filled, fontcolor=white];
+}
+`, sb.String())
+}
+
+func (suite *DOTSuite) TestStructure() {
+	tree := Make[int, string]()
+	// Expected tree view:
+	// 				2(B)
+	//			  /		 \
+	// 			1(B)	 3(B)
+	//					    \
+	//					    4(R)
+	for _, k := range []int{1, 2, 3, 4} {
+		tree.Insert(k, fmt.Sprint("v", k))
+	}
+
+	var sb strings.Builder
+	assert.NoError(suite.T(), tree.WriteDOT(&sb, nil))
+	assert.Equal(suite.T(), `digraph rbt {
+	graph [ordering=out];
+	node [style=filled, fontcolor=white];
+	n0 [label="2", fillcolor=black];
+	n1 [label="1", fillcolor=black];
+	n0 -> n1;
+	n2 [label="3", fillcolor=black];
+	n3 [style=invis];
+	n2 -> n3 [style=invis];
+	n4 [label="4", fillcolor=red];
+	n2 -> n4;
+	n0 -> n2;
+}
+`, sb.String())
+}
+
+func (suite *DOTSuite) TestOptions() {
+	tree := Make[int, string]()
+	tree.Insert(1, `say "hi"`)
+
+	var sb strings.Builder
+	assert.NoError(suite.T(), tree.WriteDOT(&sb, &DOTOptions[int, string]{
+		NilLeaves: true,
+		Label:     func(k int, v string) string { return fmt.Sprintf("%d: %s", k, v) },
+	}))
+	assert.Equal(suite.T(), `digraph rbt {
+	graph [ordering=out];
+	node [style=filled, fontcolor=white];
+	n0 [label="1: say \"hi\"", fillcolor=black];
+	n1 [shape=box, label="", width=0.2, height=0.2, fillcolor=black];
+	n0 -> n1;
+	n2 [shape=box, label="", width=0.2, height=0.2, fillcolor=black];
+	n0 -> n2;
+}
+`, sb.String())
+}
+
+func (suite *DOTSuite) TestWriteError() {
+	tree := Make[int, int]()
+	tree.Insert(1, 1)
+	assert.Error(suite.T(), tree.WriteDOT(failingWriter{}, nil))
+}
+
+func TestDOTSuite(t *testing.T) {
+	suite.Run(t, new(DOTSuite))
+}
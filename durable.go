@@ -0,0 +1,362 @@
+package rbt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"os"
+	"path/filepath"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Files of a durable tree directory
+const (
+	snapshotFile = "snapshot"
+	walFile      = "wal"
+)
+
+// Operations recorded in a write-ahead log
+const (
+	walInsert = byte(iota + 1)
+	walRemove
+)
+
+// ErrLogFailed is returned by mutations of a durable tree, which log could not be restored after a failed write
+var ErrLogFailed = errors.New("write-ahead log is in unknown state")
+
+/*
+SnapshotError is returned by a mutation, which is logged and applied, when the following automatic snapshot fails.
+The operation is durable regardless, as it remains in the log.
+*/
+type SnapshotError struct {
+	Err error
+}
+
+func (e *SnapshotError) Error() string {
+	return fmt.Sprintf("operation is applied, but snapshot failed: %v", e.Err)
+}
+
+func (e *SnapshotError) Unwrap() error {
+	return e.Err
+}
+
+// logFile is a file of a write-ahead log
+type logFile interface {
+	io.ReadWriteSeeker
+	io.Closer
+	Truncate(size int64) error
+	Sync() error
+}
+
+// DurableOptions configure a durable tree
+type DurableOptions[K constraints.Ordered, V any] struct {
+	// Codecs used for the snapshot and the log, nil means DefaultCodec
+	KeyCodec   Codec[K]
+	ValueCodec Codec[V]
+	// Number of logged operations after which a snapshot is written automatically, 0 disables it
+	SnapshotEvery int
+	// Whether every logged operation is synced to disk before it is applied
+	Sync bool
+}
+
+/*
+DurableTree is a RedBlackTree, which survives restarts.
+Every mutation is appended to a write-ahead log before being applied in memory,
+and a full sorted snapshot replaces the log from time to time.
+*/
+type DurableTree[K constraints.Ordered, V any] struct {
+	tree       *RedBlackTree[K, V]
+	dir        string
+	wal        logFile
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+	opts       DurableOptions[K, V]
+	logged     int   // Number of operations in the log
+	offset     int64 // End of the last complete record of the log
+	failed     error // Set when the log could not be restored after a failed write
+}
+
+/*
+Function Open opens a durable tree stored in a directory, creating it if needed.
+The tree is rebuilt from the snapshot followed by operations of the log.
+A torn record at the end of the log, left by a crash in the middle of a write, is discarded.
+Options may be nil, in which case defaults are used.
+*/
+func Open[K constraints.Ordered, V any](path string, opts *DurableOptions[K, V]) (*DurableTree[K, V], error) {
+	if opts == nil {
+		opts = &DurableOptions[K, V]{}
+	}
+	if err := os.MkdirAll(path, 0o755); err != nil {
+		return nil, err
+	}
+
+	dt := &DurableTree[K, V]{tree: Make[K, V](), dir: path, opts: *opts}
+	dt.keyCodec, dt.valueCodec = withDefaultCodecs(opts.KeyCodec, opts.ValueCodec)
+
+	if err := dt.loadSnapshot(); err != nil {
+		return nil, err
+	}
+
+	wal, err := os.OpenFile(filepath.Join(path, walFile), os.O_RDWR|os.O_CREATE, 0o644)
+	if err != nil {
+		return nil, err
+	}
+	dt.wal = wal
+
+	if err := dt.replay(); err != nil {
+		wal.Close()
+		return nil, err
+	}
+	return dt, nil
+}
+
+func (dt *DurableTree[K, V]) loadSnapshot() error {
+	f, err := os.Open(filepath.Join(dt.dir, snapshotFile))
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	} else if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	dec := NewStreamDecoder[K, V](f)
+	dec.SetCodecs(dt.keyCodec, dt.valueCodec)
+	if err := dec.Decode(dt.tree); err != nil {
+		return fmt.Errorf("loading snapshot: %w", err)
+	}
+	return nil
+}
+
+// Function replay applies all complete records of the log and truncates the log after them
+func (dt *DurableTree[K, V]) replay() error {
+	r := bufio.NewReader(dt.wal)
+	offset := int64(0)
+
+	for {
+		record, err := readChunk(r)
+		if err != nil {
+			break
+		}
+		var sum [4]byte
+		if _, err := io.ReadFull(r, sum[:]); err != nil {
+			break
+		}
+		if binary.BigEndian.Uint32(sum[:]) != crc32.ChecksumIEEE(record.Bytes()) {
+			break
+		}
+
+		var prefix [binary.MaxVarintLen64]byte
+		size := int64(binary.PutUvarint(prefix[:], uint64(record.Len()))) + int64(record.Len()) + int64(len(sum))
+		if err := dt.apply(&record); err != nil {
+			return fmt.Errorf("replaying log at offset %d: %w", offset, err)
+		}
+		offset += size
+		dt.logged++
+	}
+
+	return dt.rewind(offset)
+}
+
+/*
+Function rewind truncates the log at a given offset and positions it there, discarding everything written after.
+If the log cannot be rewound, its contents are unknown, so the tree is marked as failed.
+*/
+func (dt *DurableTree[K, V]) rewind(offset int64) error {
+	err := dt.wal.Truncate(offset)
+	if err == nil {
+		_, err = dt.wal.Seek(offset, io.SeekStart)
+	}
+	if err == nil {
+		err = dt.wal.Sync()
+	}
+	if err != nil {
+		dt.failed = fmt.Errorf("%w: %v", ErrLogFailed, err)
+		return err
+	}
+
+	dt.offset = offset
+	return nil
+}
+
+func (dt *DurableTree[K, V]) apply(record *bytes.Buffer) error {
+	op, err := record.ReadByte()
+	if err != nil {
+		return err
+	}
+	k, err := dt.keyCodec.Decode(record)
+	if err != nil {
+		return err
+	}
+
+	switch op {
+	case walInsert:
+		v, err := dt.valueCodec.Decode(record)
+		if err != nil {
+			return err
+		}
+		dt.tree.Insert(k, v)
+	case walRemove:
+		dt.tree.Remove(k)
+	default:
+		return fmt.Errorf("%w: unknown log operation %d", ErrInvalidFormat, op)
+	}
+	return nil
+}
+
+/*
+Function log appends a record to the log with a single write.
+A failed write or sync is rolled back, so that neither a partial record hides following ones on replay,
+nor an operation reported as failed is replayed after a restart.
+*/
+func (dt *DurableTree[K, V]) log(op byte, k K, v *V) error {
+	if dt.failed != nil {
+		return dt.failed
+	}
+
+	var payload bytes.Buffer
+	payload.WriteByte(op)
+	if err := dt.keyCodec.Encode(&payload, k); err != nil {
+		return err
+	}
+	if v != nil {
+		if err := dt.valueCodec.Encode(&payload, *v); err != nil {
+			return err
+		}
+	}
+
+	record := binary.AppendUvarint(nil, uint64(payload.Len()))
+	record = append(record, payload.Bytes()...)
+	record = binary.BigEndian.AppendUint32(record, crc32.ChecksumIEEE(payload.Bytes()))
+	_, err := dt.wal.Write(record)
+	if err == nil && dt.opts.Sync {
+		err = dt.wal.Sync()
+	}
+	if err != nil {
+		dt.rewind(dt.offset)
+		return err
+	}
+
+	dt.offset += int64(len(record))
+	dt.logged++
+	return nil
+}
+
+func (dt *DurableTree[K, V]) maybeSnapshot() error {
+	if dt.opts.SnapshotEvery > 0 && dt.logged >= dt.opts.SnapshotEvery {
+		if err := dt.Snapshot(); err != nil {
+			return &SnapshotError{Err: err}
+		}
+	}
+	return nil
+}
+
+/*
+Function Insert logs and puts a value into a tree. If key already exists - function updates it's value.
+The tree is not modified if logging fails. A *SnapshotError means that the value is stored nonetheless.
+*/
+func (dt *DurableTree[K, V]) Insert(k K, v V) error {
+	if err := dt.log(walInsert, k, &v); err != nil {
+		return err
+	}
+	dt.tree.Insert(k, v)
+	return dt.maybeSnapshot()
+}
+
+/*
+Function Remove logs and removes given key from a tree.
+The tree is not modified if logging fails. A *SnapshotError means that the key is removed nonetheless.
+*/
+func (dt *DurableTree[K, V]) Remove(k K) error {
+	if err := dt.log(walRemove, k, nil); err != nil {
+		return err
+	}
+	dt.tree.Remove(k)
+	return dt.maybeSnapshot()
+}
+
+// Function Search performs lookup of a value by key
+func (dt *DurableTree[K, V]) Search(k K) (V, bool) {
+	return dt.tree.Search(k)
+}
+
+// Function Keys returns a collection of keys in ascending order
+func (dt *DurableTree[K, V]) Keys() []K {
+	return dt.tree.Keys()
+}
+
+// Function Traverse applies a closure to every entry in ascending key order
+func (dt *DurableTree[K, V]) Traverse(closure func(k K, v V)) {
+	dt.tree.Traverse(closure)
+}
+
+// Function Size returns a number of elements stored in a tree
+func (dt *DurableTree[K, V]) Size() int {
+	return dt.tree.Size()
+}
+
+/*
+Function Snapshot writes a full sorted snapshot of a tree and empties the log.
+Snapshot is written into a temporary file, which atomically replaces the previous snapshot.
+A crash before the log is emptied is harmless, as replaying operations on top of
+the snapshot that already includes them yields the same tree.
+Emptying the log recovers a tree, which failed with ErrLogFailed.
+*/
+func (dt *DurableTree[K, V]) Snapshot() error {
+	tmp := filepath.Join(dt.dir, snapshotFile+".tmp")
+	f, err := os.Create(tmp)
+	if err != nil {
+		return err
+	}
+
+	bw := bufio.NewWriter(f)
+	enc := NewStreamEncoder[K, V](bw)
+	enc.SetCodecs(dt.keyCodec, dt.valueCodec)
+	err = enc.Encode(dt.tree)
+	if err == nil {
+		err = bw.Flush()
+	}
+	if err == nil {
+		err = f.Sync()
+	}
+	if closeErr := f.Close(); err == nil {
+		err = closeErr
+	}
+	if err != nil {
+		os.Remove(tmp)
+		return err
+	}
+
+	if err := os.Rename(tmp, filepath.Join(dt.dir, snapshotFile)); err != nil {
+		return err
+	}
+	if err := syncDir(dt.dir); err != nil {
+		return err
+	}
+
+	if err := dt.rewind(0); err != nil {
+		return err
+	}
+	dt.logged = 0
+	dt.failed = nil
+	return nil
+}
+
+// Function Close closes the log. The tree must not be used afterwards
+func (dt *DurableTree[K, V]) Close() error {
+	return dt.wal.Close()
+}
+
+// Function syncDir makes a rename within a directory durable
+func syncDir(path string) error {
+	d, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
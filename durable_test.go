@@ -0,0 +1,268 @@
+package rbt
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/stretchr/testify/suite"
+)
+
+type DurableSuite struct {
+	suite.Suite
+}
+
+type durableOp struct {
+	remove bool
+	key    int
+	value  string
+}
+
+func makeDurableOps(n int) []durableOp {
+	ops := make([]durableOp, 0, n)
+	for i := 0; i < n; i++ {
+		if i%4 == 3 {
+			ops = append(ops, durableOp{remove: true, key: (i * 7) % 13})
+		} else {
+			ops = append(ops, durableOp{key: (i * 7) % 13, value: strconv.Itoa(i)})
+		}
+	}
+	return ops
+}
+
+// Applies operations to an in-memory tree, producing the expected state
+func applyDurableOps(ops []durableOp) map[int]string {
+	tree := Make[int, string]()
+	for _, op := range ops {
+		if op.remove {
+			tree.Remove(op.key)
+		} else {
+			tree.Insert(op.key, op.value)
+		}
+	}
+
+	result := map[int]string{}
+	tree.Traverse(func(k int, v string) { result[k] = v })
+	return result
+}
+
+func durableContents(dt *DurableTree[int, string]) map[int]string {
+	result := map[int]string{}
+	dt.Traverse(func(k int, v string) { result[k] = v })
+	return result
+}
+
+func (suite *DurableSuite) TestReopen() {
+	dir := suite.T().TempDir()
+	ops := makeDurableOps(50)
+
+	dt, err := Open[int, string](dir, &DurableOptions[int, string]{SnapshotEvery: 16, Sync: true})
+	require.NoError(suite.T(), err)
+	for _, op := range ops {
+		if op.remove {
+			assert.NoError(suite.T(), dt.Remove(op.key))
+		} else {
+			assert.NoError(suite.T(), dt.Insert(op.key, op.value))
+		}
+	}
+	expected := applyDurableOps(ops)
+	assert.Equal(suite.T(), expected, durableContents(dt))
+	assert.NoError(suite.T(), dt.Close())
+
+	// 50 operations produce 3 snapshots and 2 logged operations
+	assert.FileExists(suite.T(), filepath.Join(dir, snapshotFile))
+	assert.NoFileExists(suite.T(), filepath.Join(dir, snapshotFile+".tmp"))
+
+	dt, err = Open[int, string](dir, nil)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), expected, durableContents(dt))
+	assert.Equal(suite.T(), 2, dt.logged)
+	assert.NoError(suite.T(), dt.tree.Validate())
+
+	assert.NoError(suite.T(), dt.Insert(100, "new"))
+	assert.NoError(suite.T(), dt.Snapshot())
+	assert.NoError(suite.T(), dt.Remove(100))
+	assert.NoError(suite.T(), dt.Close())
+
+	dt, err = Open[int, string](dir, nil)
+	require.NoError(suite.T(), err)
+	_, found := dt.Search(100)
+	assert.False(suite.T(), found)
+	assert.Equal(suite.T(), len(expected), dt.Size())
+	assert.NoError(suite.T(), dt.Close())
+}
+
+func (suite *DurableSuite) TestTruncatedLog() {
+	dir := suite.T().TempDir()
+	ops := makeDurableOps(30)
+
+	dt, err := Open[int, string](dir, nil)
+	require.NoError(suite.T(), err)
+	// Snapshot holds the first 10 operations
+	ends := []int64{}
+	for i, op := range ops {
+		if op.remove {
+			assert.NoError(suite.T(), dt.Remove(op.key))
+		} else {
+			assert.NoError(suite.T(), dt.Insert(op.key, op.value))
+		}
+		if i == 9 {
+			assert.NoError(suite.T(), dt.Snapshot())
+		}
+		if i >= 10 {
+			ends = append(ends, dt.offset)
+		}
+	}
+	assert.NoError(suite.T(), dt.Close())
+
+	snapshot, err := os.ReadFile(filepath.Join(dir, snapshotFile))
+	require.NoError(suite.T(), err)
+	wal, err := os.ReadFile(filepath.Join(dir, walFile))
+	require.NoError(suite.T(), err)
+
+	// Simulate a crash at every byte of the log
+	for cut := 0; cut <= len(wal); cut++ {
+		crashed := suite.T().TempDir()
+		require.NoError(suite.T(), os.WriteFile(filepath.Join(crashed, snapshotFile), snapshot, 0o644))
+		require.NoError(suite.T(), os.WriteFile(filepath.Join(crashed, walFile), wal[:cut], 0o644))
+
+		complete := 0
+		for complete < len(ends) && ends[complete] <= int64(cut) {
+			complete++
+		}
+
+		dt, err := Open[int, string](crashed, nil)
+		require.NoError(suite.T(), err, "cut at %d", cut)
+		assert.Equal(suite.T(), applyDurableOps(ops[:10+complete]), durableContents(dt), "cut at %d", cut)
+
+		// Torn record is discarded, so new operations are appended after the last complete one
+		assert.NoError(suite.T(), dt.Insert(-1, "after crash"))
+		assert.NoError(suite.T(), dt.Close())
+
+		dt, err = Open[int, string](crashed, nil)
+		require.NoError(suite.T(), err)
+		v, _ := dt.Search(-1)
+		assert.Equal(suite.T(), "after crash", v)
+		assert.Equal(suite.T(), complete+1, dt.logged)
+		assert.NoError(suite.T(), dt.Close())
+	}
+}
+
+/*
+Log, which operations fail on demand. A failed write leaves a partial record, as a short write would.
+Sync fails only once, as a transient failure.
+*/
+type faultyLog struct {
+	*os.File
+	failWrite, failSync, failTruncate bool
+}
+
+func (f *faultyLog) Write(p []byte) (int, error) {
+	if f.failWrite {
+		n, _ := f.File.Write(p[:len(p)/2])
+		return n, errors.New("disk full")
+	}
+	return f.File.Write(p)
+}
+
+func (f *faultyLog) Sync() error {
+	if f.failSync {
+		f.failSync = false
+		return errors.New("sync failed")
+	}
+	return f.File.Sync()
+}
+
+func (f *faultyLog) Truncate(size int64) error {
+	if f.failTruncate {
+		return errors.New("truncate failed")
+	}
+	return f.File.Truncate(size)
+}
+
+func (suite *DurableSuite) TestFailedWrite() {
+	dir := suite.T().TempDir()
+
+	dt, err := Open[int, string](dir, &DurableOptions[int, string]{Sync: true})
+	require.NoError(suite.T(), err)
+	log := &faultyLog{File: dt.wal.(*os.File)}
+	dt.wal = log
+
+	assert.NoError(suite.T(), dt.Insert(1, "one"))
+
+	// Partial record is rolled back, so a later acknowledged write is not hidden behind it
+	log.failWrite = true
+	assert.Error(suite.T(), dt.Insert(2, "two"))
+	log.failWrite = false
+	assert.NoError(suite.T(), dt.Insert(3, "three"))
+
+	// Operation, which failed to sync, is not replayed
+	log.failSync = true
+	assert.Error(suite.T(), dt.Remove(1))
+	assert.NoError(suite.T(), dt.Insert(4, "four"))
+
+	assert.Equal(suite.T(), []int{1, 3, 4}, dt.Keys())
+	assert.NoError(suite.T(), dt.Close())
+
+	dt, err = Open[int, string](dir, nil)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{1, 3, 4}, dt.Keys())
+	assert.Equal(suite.T(), 3, dt.logged)
+
+	// Log, which cannot be rolled back, rejects further mutations until a snapshot empties it
+	log = &faultyLog{File: dt.wal.(*os.File), failWrite: true, failTruncate: true}
+	dt.wal = log
+	assert.Error(suite.T(), dt.Insert(5, "five"))
+	log.failWrite, log.failTruncate = false, false
+	assert.ErrorIs(suite.T(), dt.Insert(6, "six"), ErrLogFailed)
+	assert.ErrorIs(suite.T(), dt.Remove(1), ErrLogFailed)
+	assert.Equal(suite.T(), []int{1, 3, 4}, dt.Keys())
+
+	assert.NoError(suite.T(), dt.Snapshot())
+	assert.NoError(suite.T(), dt.Insert(7, "seven"))
+	assert.NoError(suite.T(), dt.Close())
+
+	dt, err = Open[int, string](dir, nil)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{1, 3, 4, 7}, dt.Keys())
+	assert.NoError(suite.T(), dt.Close())
+}
+
+func (suite *DurableSuite) TestFailedSnapshot() {
+	dir := suite.T().TempDir()
+	// Directory in place of a temporary snapshot file makes every snapshot fail
+	require.NoError(suite.T(), os.Mkdir(filepath.Join(dir, snapshotFile+".tmp"), 0o755))
+
+	dt, err := Open[int, string](dir, &DurableOptions[int, string]{SnapshotEvery: 1})
+	require.NoError(suite.T(), err)
+
+	err = dt.Insert(1, "one")
+	var snapshotErr *SnapshotError
+	assert.ErrorAs(suite.T(), err, &snapshotErr)
+	v, found := dt.Search(1)
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), "one", v)
+	assert.NoError(suite.T(), dt.Close())
+
+	// Operation stays in the log
+	dt, err = Open[int, string](dir, nil)
+	require.NoError(suite.T(), err)
+	assert.Equal(suite.T(), []int{1}, dt.Keys())
+	assert.NoError(suite.T(), dt.Close())
+}
+
+func (suite *DurableSuite) TestCorruptedSnapshot() {
+	dir := suite.T().TempDir()
+	require.NoError(suite.T(), os.WriteFile(filepath.Join(dir, snapshotFile), []byte("garbage"), 0o644))
+
+	_, err := Open[int, string](dir, nil)
+	assert.ErrorIs(suite.T(), err, ErrInvalidFormat)
+}
+
+func TestDurableSuite(t *testing.T) {
+	suite.Run(t, new(DurableSuite))
+}
@@ -0,0 +1,76 @@
+package rbt
+
+/*
+A *Node serves as a stable handle of an entry: it keeps its key and value while
+the entry stays in a tree, regardless of other insertions and removals.
+Trees made with MakePooled reuse nodes of removed entries, so there a handle is valid only until removal.
+It allows to access an entry without a lookup, to remove it, and to iterate from it.
+*/
+
+// Function Key returns a key stored in a node
+func (node *Node[K, V]) Key() K {
+	return node.key
+}
+
+// Function Value returns a value stored in a node
+func (node *Node[K, V]) Value() V {
+	return node.value
+}
+
+/*
+Function Next returns a node with the next greater key, or nil if there is none.
+It takes O(1) amortized time when iterating over a whole tree.
+*/
+func (node *Node[K, V]) Next() *Node[K, V] {
+	if node.right != nil {
+		return node.right.minimum()
+	}
+
+	for node.parent != nil && node == node.parent.right {
+		node = node.parent
+	}
+	return node.parent
+}
+
+// Function Prev returns a node with the next smaller key, or nil if there is none
+func (node *Node[K, V]) Prev() *Node[K, V] {
+	if node.left != nil {
+		return node.left.maximum()
+	}
+
+	for node.parent != nil && node == node.parent.left {
+		node = node.parent
+	}
+	return node.parent
+}
+
+// Function Handle returns a handle of an entry with a given key, or nil if key is not found
+func (tree *RedBlackTree[K, V]) Handle(k K) *Node[K, V] {
+	return tree.search(k)
+}
+
+// Function First returns a handle of an entry with the smallest key, or nil if tree is empty
+func (tree *RedBlackTree[K, V]) First() *Node[K, V] {
+	return tree.root.minimum()
+}
+
+// Function Last returns a handle of an entry with the largest key, or nil if tree is empty
+func (tree *RedBlackTree[K, V]) Last() *Node[K, V] {
+	return tree.root.maximum()
+}
+
+/*
+Function RemoveHandle removes an entry by its handle in O(log n), without a lookup.
+Handle must belong to the tree. Returns false if the entry is already removed.
+*/
+func (tree *RedBlackTree[K, V]) RemoveHandle(node *Node[K, V]) bool {
+	// Removed nodes are detached, so only the root has no parent among the stored ones
+	if node == nil || (node.parent == nil && node != tree.root) {
+		return false
+	}
+
+	tree.delete(node)
+	tree.notifyRemove(node.key, node.value)
+	tree.recycle(node)
+	return true
+}
@@ -0,0 +1,131 @@
+package rbt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type HandleSuite struct {
+	suite.Suite
+}
+
+func (suite *HandleSuite) TestStableAcrossDeletions() {
+	const n = 1000
+	tree := Make[int, int]()
+	for i := 0; i < n; i++ {
+		tree.Insert(i, i*10)
+	}
+
+	handles := make([]*Node[int, int], n)
+	for i := range handles {
+		handles[i] = tree.Handle(i)
+	}
+
+	rng := rand.New(rand.NewSource(1))
+	removed := map[int]bool{}
+	for _, i := range rng.Perm(n)[:n/2] {
+		tree.Remove(i)
+		removed[i] = true
+	}
+	assert.NoError(suite.T(), tree.Validate())
+
+	for i, h := range handles {
+		if removed[i] {
+			assert.Nil(suite.T(), tree.Handle(i))
+			continue
+		}
+		assert.Equal(suite.T(), i, h.Key())
+		assert.Equal(suite.T(), i*10, h.Value())
+		assert.Same(suite.T(), h, tree.Handle(i))
+	}
+}
+
+func (suite *HandleSuite) TestSuccessorKeepsIdentity() {
+	// Expected tree view:
+	// 				2(B)
+	//			  /		 \
+	// 			1(B)	 3(B)
+	//					    \
+	//					    4(R)
+	tree := Make[int, string]()
+	for _, k := range []int{1, 2, 3, 4} {
+		tree.Insert(k, string(rune('a'+k)))
+	}
+
+	successor := tree.Handle(3)
+	removed := tree.Handle(2)
+	tree.Remove(2)
+
+	// Successor is relinked into the place of the removed root
+	assert.Same(suite.T(), successor, tree.root)
+	assert.Equal(suite.T(), 3, successor.Key())
+	assert.Equal(suite.T(), "d", successor.Value())
+	// Removed node keeps its payload, but is detached
+	assert.Equal(suite.T(), 2, removed.Key())
+	assert.Nil(suite.T(), removed.Next())
+	assert.Nil(suite.T(), removed.Prev())
+}
+
+func (suite *HandleSuite) TestIteration() {
+	tree := Make[int, int]()
+	assert.Nil(suite.T(), tree.First())
+	assert.Nil(suite.T(), tree.Last())
+
+	for _, k := range []int{60, 25, 17, 5, 40, 8, 15, 18} {
+		tree.Insert(k, k)
+	}
+
+	forward := []int{}
+	for h := tree.First(); h != nil; h = h.Next() {
+		forward = append(forward, h.Key())
+	}
+	assert.Equal(suite.T(), tree.Keys(), forward)
+
+	backward := []int{}
+	for h := tree.Last(); h != nil; h = h.Prev() {
+		backward = append(backward, h.Key())
+	}
+	assert.Equal(suite.T(), []int{60, 40, 25, 18, 17, 15, 8, 5}, backward)
+
+	// Iteration position survives removal of other entries
+	h := tree.Handle(17)
+	tree.Remove(18)
+	tree.Remove(15)
+	assert.Equal(suite.T(), 25, h.Next().Key())
+	assert.Equal(suite.T(), 8, h.Prev().Key())
+}
+
+func (suite *HandleSuite) TestRemoveHandle() {
+	tree := Make[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+
+	removed := []int{}
+	tree.Observe(Observer[int, int]{Remove: func(k, v int) { removed = append(removed, k) }})
+
+	root := tree.root
+	assert.True(suite.T(), tree.RemoveHandle(root))
+	assert.False(suite.T(), tree.RemoveHandle(root))
+	assert.False(suite.T(), tree.RemoveHandle(nil))
+
+	h := tree.Handle(7)
+	assert.True(suite.T(), tree.RemoveHandle(h))
+	assert.False(suite.T(), tree.RemoveHandle(h))
+
+	assert.Equal(suite.T(), 8, tree.Size())
+	assert.Equal(suite.T(), []int{root.Key(), 7}, removed)
+	assert.NoError(suite.T(), tree.Validate())
+
+	for h := tree.First(); h != nil; h = tree.First() {
+		assert.True(suite.T(), tree.RemoveHandle(h))
+	}
+	assert.Equal(suite.T(), 0, tree.Size())
+}
+
+func TestHandleSuite(t *testing.T) {
+	suite.Run(t, new(HandleSuite))
+}
@@ -0,0 +1,155 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+// Intervals sharing the same start are kept together in a single tree node
+type intervalEntry[T constraints.Ordered, V any] struct {
+	end   T
+	value V
+}
+
+type intervalBucket[T constraints.Ordered, V any] struct {
+	entries []intervalEntry[T, V]
+	maxEnd  T // Maximum end of all intervals stored in the node's subtree
+}
+
+/*
+IntervalTree stores half-open intervals [start, end) with associated values.
+It is a Red-Black tree keyed by interval start, where every node is augmented
+with the maximum interval end of its subtree, which allows to skip subtrees
+that can not contain overlapping intervals during queries.
+*/
+type IntervalTree[T constraints.Ordered, V any] struct {
+	tree *RedBlackTree[T, *intervalBucket[T, V]]
+	size int
+}
+
+// Function MakeIntervalTree creates empty instance of an interval tree
+func MakeIntervalTree[T constraints.Ordered, V any]() *IntervalTree[T, V] {
+	it := &IntervalTree[T, V]{tree: Make[T, *intervalBucket[T, V]]()}
+	it.tree.augment = updateMaxEnd[T, V]
+	return it
+}
+
+func updateMaxEnd[T constraints.Ordered, V any](n *Node[T, *intervalBucket[T, V]]) {
+	b := n.value
+	b.maxEnd = b.entries[0].end
+	for _, e := range b.entries[1:] {
+		if e.end > b.maxEnd {
+			b.maxEnd = e.end
+		}
+	}
+
+	if n.left != nil && n.left.value.maxEnd > b.maxEnd {
+		b.maxEnd = n.left.value.maxEnd
+	}
+	if n.right != nil && n.right.value.maxEnd > b.maxEnd {
+		b.maxEnd = n.right.value.maxEnd
+	}
+}
+
+/*
+Function Insert puts an interval [start, end) with its value into a tree.
+Intervals are not deduplicated: inserting the same interval twice stores it twice.
+It panics if start is not less than end.
+*/
+func (it *IntervalTree[T, V]) Insert(start, end T, v V) {
+	if !(start < end) {
+		panic("invalid interval: start is not less than end")
+	}
+
+	entry := intervalEntry[T, V]{end: end, value: v}
+	if n := it.tree.search(start); n != nil {
+		n.value.entries = append(n.value.entries, entry)
+		it.tree.refreshPath(n)
+	} else {
+		it.tree.Insert(start, &intervalBucket[T, V]{entries: []intervalEntry[T, V]{entry}})
+	}
+
+	it.size++
+}
+
+/*
+Function Remove removes a single interval [start, end) from a tree.
+If several equal intervals are stored, the earliest inserted one is removed.
+Returns whether an interval was found.
+*/
+func (it *IntervalTree[T, V]) Remove(start, end T) bool {
+	n := it.tree.search(start)
+	if n == nil {
+		return false
+	}
+
+	entries := n.value.entries
+	for i := range entries {
+		if entries[i].end != end {
+			continue
+		}
+
+		if len(entries) == 1 {
+			it.tree.delete(n)
+		} else {
+			n.value.entries = append(entries[:i], entries[i+1:]...)
+			it.tree.refreshPath(n)
+		}
+		it.size--
+		return true
+	}
+
+	return false
+}
+
+/*
+Function Overlapping applies a closure to every interval that overlaps [lo, hi),
+in the order of interval starts.
+*/
+func (it *IntervalTree[T, V]) Overlapping(lo, hi T, closure func(start, end T, v V)) {
+	overlapping(it.tree.root, lo, func(start T) bool { return start < hi }, closure)
+}
+
+// Function Stabbing applies a closure to every interval that contains a point
+func (it *IntervalTree[T, V]) Stabbing(point T, closure func(start, end T, v V)) {
+	overlapping(it.tree.root, point, func(start T) bool { return start <= point }, closure)
+}
+
+// Function Traverse applies a closure to every interval in the order of interval starts
+func (it *IntervalTree[T, V]) Traverse(closure func(start, end T, v V)) {
+	it.tree.root.inorder(func(n *Node[T, *intervalBucket[T, V]]) {
+		for _, e := range n.value.entries {
+			closure(n.key, e.end, e.value)
+		}
+	})
+}
+
+// Function Size returns a number of intervals stored in a tree
+func (it *IntervalTree[T, V]) Size() int {
+	return it.size
+}
+
+/*
+Function overlapping visits intervals which end after lo and which start satisfies a predicate.
+Predicate has to be monotonic: once it fails for some start, it fails for all greater ones.
+*/
+func overlapping[T constraints.Ordered, V any](n *Node[T, *intervalBucket[T, V]], lo T, startOK func(start T) bool, closure func(start, end T, v V)) {
+	// No interval in this subtree ends after lo
+	if n == nil || n.value.maxEnd <= lo {
+		return
+	}
+
+	overlapping(n.left, lo, startOK, closure)
+
+	// Intervals in the right subtree start even later
+	if !startOK(n.key) {
+		return
+	}
+
+	for _, e := range n.value.entries {
+		if e.end > lo {
+			closure(n.key, e.end, e.value)
+		}
+	}
+
+	overlapping(n.right, lo, startOK, closure)
+}
@@ -0,0 +1,176 @@
+package rbt
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type IntervalTreeSuite struct {
+	suite.Suite
+}
+
+type interval struct {
+	start, end int
+}
+
+func collectOverlapping(it *IntervalTree[int, int], lo, hi int) []interval {
+	result := []interval{}
+	it.Overlapping(lo, hi, func(start, end, v int) {
+		result = append(result, interval{start, end})
+	})
+	return result
+}
+
+func collectStabbing(it *IntervalTree[int, int], point int) []interval {
+	result := []interval{}
+	it.Stabbing(point, func(start, end, v int) {
+		result = append(result, interval{start, end})
+	})
+	return result
+}
+
+// Checks that every node holds the maximum end of its subtree
+func hasValidMaxEnd(n *Node[int, *intervalBucket[int, int]]) bool {
+	if n == nil {
+		return true
+	}
+
+	expected := n.value.entries[0].end
+	for _, e := range n.value.entries {
+		if e.end > expected {
+			expected = e.end
+		}
+	}
+	if n.left != nil && n.left.value.maxEnd > expected {
+		expected = n.left.value.maxEnd
+	}
+	if n.right != nil && n.right.value.maxEnd > expected {
+		expected = n.right.value.maxEnd
+	}
+
+	return n.value.maxEnd == expected && hasValidMaxEnd(n.left) && hasValidMaxEnd(n.right)
+}
+
+func (suite *IntervalTreeSuite) TestOverlapping() {
+	it := MakeIntervalTree[int, int]()
+	for i, iv := range []interval{{15, 20}, {10, 30}, {17, 19}, {5, 20}, {12, 15}, {30, 40}} {
+		it.Insert(iv.start, iv.end, i)
+	}
+
+	assert.Equal(suite.T(), 6, it.Size())
+	assert.True(suite.T(), it.tree.isValidRBTree())
+	assert.True(suite.T(), hasValidMaxEnd(it.tree.root))
+
+	assert.Equal(suite.T(), []interval{{5, 20}, {10, 30}, {12, 15}}, collectOverlapping(it, 6, 15))
+	assert.Equal(suite.T(), []interval{{10, 30}, {30, 40}}, collectOverlapping(it, 25, 35))
+	// Intervals are half-open, so touching ones do not overlap
+	assert.Equal(suite.T(), []interval{}, collectOverlapping(it, 40, 50))
+	assert.Equal(suite.T(), []interval{}, collectOverlapping(it, 0, 5))
+}
+
+func (suite *IntervalTreeSuite) TestStabbing() {
+	it := MakeIntervalTree[int, int]()
+	for i, iv := range []interval{{15, 20}, {10, 30}, {17, 19}, {5, 20}, {12, 15}, {30, 40}} {
+		it.Insert(iv.start, iv.end, i)
+	}
+
+	assert.Equal(suite.T(), []interval{{5, 20}, {10, 30}, {15, 20}, {17, 19}}, collectStabbing(it, 18))
+	assert.Equal(suite.T(), []interval{{5, 20}, {10, 30}, {15, 20}}, collectStabbing(it, 15))
+	assert.Equal(suite.T(), []interval{{30, 40}}, collectStabbing(it, 30))
+	assert.Equal(suite.T(), []interval{}, collectStabbing(it, 40))
+}
+
+func (suite *IntervalTreeSuite) TestSameStart() {
+	it := MakeIntervalTree[int, string]()
+	it.Insert(1, 3, "a")
+	it.Insert(1, 10, "b")
+	it.Insert(1, 3, "c")
+
+	assert.Equal(suite.T(), 3, it.Size())
+	assert.Equal(suite.T(), 1, it.tree.Size())
+
+	values := []string{}
+	it.Stabbing(2, func(start, end int, v string) {
+		values = append(values, v)
+	})
+	assert.Equal(suite.T(), []string{"a", "b", "c"}, values)
+
+	assert.True(suite.T(), it.Remove(1, 3))
+	assert.False(suite.T(), it.Remove(1, 4))
+	assert.Equal(suite.T(), 2, it.Size())
+
+	values = values[:0]
+	it.Traverse(func(start, end int, v string) {
+		values = append(values, v)
+	})
+	assert.Equal(suite.T(), []string{"b", "c"}, values)
+	assert.Equal(suite.T(), 10, it.tree.root.value.maxEnd)
+
+	assert.True(suite.T(), it.Remove(1, 10))
+	assert.Equal(suite.T(), 3, it.tree.root.value.maxEnd)
+	assert.True(suite.T(), it.Remove(1, 3))
+	assert.Equal(suite.T(), 0, it.Size())
+	assert.Nil(suite.T(), it.tree.root)
+}
+
+func (suite *IntervalTreeSuite) TestInvalidInterval() {
+	it := MakeIntervalTree[int, int]()
+	assert.Panics(suite.T(), func() { it.Insert(5, 5, 0) })
+	assert.Panics(suite.T(), func() { it.Insert(5, 1, 0) })
+}
+
+func (suite *IntervalTreeSuite) TestWithRandomIntervals() {
+	const n = 500
+	rng := rand.New(rand.NewSource(1))
+	it := MakeIntervalTree[int, int]()
+	stored := []interval{}
+
+	for i := 0; i < n; i++ {
+		start := rng.Intn(1000)
+		iv := interval{start, start + 1 + rng.Intn(100)}
+		it.Insert(iv.start, iv.end, i)
+		stored = append(stored, iv)
+	}
+
+	// Remove every third interval
+	kept := stored[:0]
+	for i, iv := range stored {
+		if i%3 == 0 {
+			assert.True(suite.T(), it.Remove(iv.start, iv.end))
+		} else {
+			kept = append(kept, iv)
+		}
+	}
+
+	assert.Equal(suite.T(), len(kept), it.Size())
+	assert.True(suite.T(), it.tree.isValidRBTree())
+	assert.True(suite.T(), hasValidMaxEnd(it.tree.root))
+
+	for q := 0; q < 100; q++ {
+		lo := rng.Intn(1100)
+		hi := lo + 1 + rng.Intn(50)
+
+		expected := 0
+		for _, iv := range kept {
+			if iv.start < hi && iv.end > lo {
+				expected++
+			}
+		}
+		assert.Len(suite.T(), collectOverlapping(it, lo, hi), expected)
+
+		expected = 0
+		for _, iv := range kept {
+			if iv.start <= lo && iv.end > lo {
+				expected++
+			}
+		}
+		assert.Len(suite.T(), collectStabbing(it, lo), expected)
+	}
+}
+
+func TestIntervalTreeSuite(t *testing.T) {
+	suite.Run(t, new(IntervalTreeSuite))
+}
@@ -0,0 +1,166 @@
+package rbt
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"golang.org/x/exp/constraints"
+)
+
+func hasStringKeys[K any]() bool {
+	return reflect.TypeOf((*K)(nil)).Elem().Kind() == reflect.String
+}
+
+/*
+Function MarshalJSON implements json.Marshaler, preserving ascending key order.
+Tree with string-like keys is encoded as a JSON object, e.g. {"a":1,"b":2}.
+Tree with any other keys is encoded as an array of [key, value] pairs, e.g. [[1,"a"],[2,"b"]].
+*/
+func (tree *RedBlackTree[K, V]) MarshalJSON() ([]byte, error) {
+	object := hasStringKeys[K]()
+
+	var buf bytes.Buffer
+	var err error
+	appendJSON := func(v any) {
+		if err != nil {
+			return
+		}
+
+		var data []byte
+		if data, err = json.Marshal(v); err == nil {
+			buf.Write(data)
+		}
+	}
+
+	if object {
+		buf.WriteByte('{')
+	} else {
+		buf.WriteByte('[')
+	}
+
+	first := true
+	tree.root.inorder(func(n *Node[K, V]) {
+		if !first {
+			buf.WriteByte(',')
+		}
+		first = false
+
+		if object {
+			appendJSON(reflect.ValueOf(n.key).String())
+			buf.WriteByte(':')
+			appendJSON(n.value)
+		} else {
+			buf.WriteByte('[')
+			appendJSON(n.key)
+			buf.WriteByte(',')
+			appendJSON(n.value)
+			buf.WriteByte(']')
+		}
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if object {
+		buf.WriteByte('}')
+	} else {
+		buf.WriteByte(']')
+	}
+	return buf.Bytes(), nil
+}
+
+/*
+Function UnmarshalJSON implements json.Unmarshaler.
+It replaces contents of a tree with decoded entries. Both forms produced by MarshalJSON are accepted,
+object form only for string-like keys. Entries do not have to be sorted, for duplicate keys the last value wins.
+JSON null leaves a tree unchanged, as well as any error.
+*/
+func (tree *RedBlackTree[K, V]) UnmarshalJSON(data []byte) error {
+	dec := json.NewDecoder(bytes.NewReader(data))
+	tok, err := dec.Token()
+	if err != nil {
+		return err
+	}
+
+	var nodes []*Node[K, V]
+	switch tok {
+	case nil:
+		return nil
+	case json.Delim('{'):
+		if !hasStringKeys[K]() {
+			return fmt.Errorf("can not decode JSON object into a tree with %T keys", *new(K))
+		}
+
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return err
+			}
+
+			var k K
+			reflect.ValueOf(&k).Elem().SetString(tok.(string))
+			var v V
+			if err := dec.Decode(&v); err != nil {
+				return err
+			}
+			nodes = append(nodes, MakeNode(k, v, black))
+		}
+	case json.Delim('['):
+		for dec.More() {
+			var pair []json.RawMessage
+			if err := dec.Decode(&pair); err != nil {
+				return err
+			}
+			if len(pair) != 2 {
+				return fmt.Errorf("expected [key, value] pair, got %d elements", len(pair))
+			}
+
+			var k K
+			var v V
+			if err := json.Unmarshal(pair[0], &k); err != nil {
+				return err
+			}
+			if err := json.Unmarshal(pair[1], &v); err != nil {
+				return err
+			}
+			nodes = append(nodes, MakeNode(k, v, black))
+		}
+	default:
+		return fmt.Errorf("can not decode JSON %v into a tree", tok)
+	}
+
+	// Consume closing delimiter
+	if _, err := dec.Token(); err != nil {
+		return err
+	}
+
+	tree.rebuild(uniqueSorted(nodes))
+	return nil
+}
+
+/*
+Function uniqueSorted returns nodes in strictly ascending key order, where the last node wins among equal keys.
+Already sorted input is returned as is.
+*/
+func uniqueSorted[K constraints.Ordered, V any](nodes []*Node[K, V]) []*Node[K, V] {
+	sorted := true
+	for i := 1; i < len(nodes) && sorted; i++ {
+		sorted = nodes[i-1].key < nodes[i].key
+	}
+	if sorted {
+		return nodes
+	}
+
+	tmp := Make[K, V]()
+	for _, n := range nodes {
+		tmp.Insert(n.key, n.value)
+	}
+
+	result := nodes[:0]
+	tmp.root.inorder(func(n *Node[K, V]) {
+		result = append(result, n)
+	})
+	return result
+}
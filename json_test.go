@@ -0,0 +1,99 @@
+package rbt
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type JSONSuite struct {
+	suite.Suite
+}
+
+var (
+	_ json.Marshaler   = (*RedBlackTree[int, int])(nil)
+	_ json.Unmarshaler = (*RedBlackTree[int, int])(nil)
+)
+
+func (suite *JSONSuite) TestObject() {
+	tree := Make[path, int]()
+	for i, k := range []path{"tenant/b", "tenant/a", "", "z\"q"} {
+		tree.Insert(k, i)
+	}
+
+	data, err := json.Marshal(tree)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `{"":2,"tenant/a":1,"tenant/b":0,"z\"q":3}`, string(data))
+
+	loaded := Make[path, int]()
+	assert.NoError(suite.T(), json.Unmarshal(data, loaded))
+	assert.NoError(suite.T(), loaded.Validate())
+	assert.Equal(suite.T(), tree.Keys(), loaded.Keys())
+	v, _ := loaded.Search("z\"q")
+	assert.Equal(suite.T(), 3, v)
+}
+
+func (suite *JSONSuite) TestArray() {
+	tree := Make[float64, point]()
+	tree.Insert(2.5, point{1, 2})
+	tree.Insert(-1, point{3, 4})
+
+	data, err := json.Marshal(tree)
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `[[-1,{"X":3,"Y":4}],[2.5,{"X":1,"Y":2}]]`, string(data))
+
+	loaded := Make[float64, point]()
+	assert.NoError(suite.T(), json.Unmarshal(data, loaded))
+	assert.Equal(suite.T(), tree.Keys(), loaded.Keys())
+	p, _ := loaded.Search(2.5)
+	assert.Equal(suite.T(), point{1, 2}, p)
+}
+
+func (suite *JSONSuite) TestEmpty() {
+	data, err := json.Marshal(Make[int, int]())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `[]`, string(data))
+
+	data, err = json.Marshal(Make[string, int]())
+	assert.NoError(suite.T(), err)
+	assert.Equal(suite.T(), `{}`, string(data))
+}
+
+func (suite *JSONSuite) TestUnsortedInput() {
+	tree := Make[int, string]()
+	assert.NoError(suite.T(), json.Unmarshal([]byte(`[[3,"c"],[1,"a"],[2,"b"],[1,"x"]]`), tree))
+	assert.NoError(suite.T(), tree.Validate())
+	assert.Equal(suite.T(), []int{1, 2, 3}, tree.Keys())
+	v, _ := tree.Search(1)
+	assert.Equal(suite.T(), "x", v)
+
+	strings := Make[string, int]()
+	assert.NoError(suite.T(), json.Unmarshal([]byte(`{"b":1,"a":2}`), strings))
+	assert.Equal(suite.T(), []string{"a", "b"}, strings.Keys())
+	// Array form is accepted for string keys as well
+	assert.NoError(suite.T(), json.Unmarshal([]byte(`[["c",3]]`), strings))
+	assert.Equal(suite.T(), []string{"c"}, strings.Keys())
+}
+
+func (suite *JSONSuite) TestInvalid() {
+	tree := Make[int, int]()
+	tree.Insert(1, 1)
+
+	for _, data := range []string{`{"a":1}`, `[[1]]`, `[[1,2,3]]`, `[["a",1]]`, `[[1,"a"]]`, `5`, `[[1,1]`, `[[1,1]]x`} {
+		assert.Error(suite.T(), json.Unmarshal([]byte(data), tree), data)
+	}
+	assert.NoError(suite.T(), json.Unmarshal([]byte(`null`), tree))
+	assert.Equal(suite.T(), []int{1}, tree.Keys(), "tree is unchanged")
+
+	var wrapped struct {
+		Tree *RedBlackTree[string, int]
+	}
+	assert.NoError(suite.T(), json.Unmarshal([]byte(`{"Tree":{"k":1}}`), &wrapped))
+	assert.Equal(suite.T(), []string{"k"}, wrapped.Tree.Keys())
+}
+
+func TestJSONSuite(t *testing.T) {
+	suite.Run(t, new(JSONSuite))
+}
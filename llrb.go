@@ -0,0 +1,284 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+type llrbNode[K constraints.Ordered, V any] struct {
+	left  *llrbNode[K, V]
+	right *llrbNode[K, V]
+	key   K
+	value V
+	red   bool // Color of a link from the parent
+}
+
+func (node *llrbNode[K, V]) isRed() bool {
+	return node != nil && node.red
+}
+
+func (node *llrbNode[K, V]) rotateLeft() *llrbNode[K, V] {
+	r := node.right
+	node.right = r.left
+	r.left = node
+	r.red, node.red = node.red, true
+	return r
+}
+
+func (node *llrbNode[K, V]) rotateRight() *llrbNode[K, V] {
+	l := node.left
+	node.left = l.right
+	l.right = node
+	l.red, node.red = node.red, true
+	return l
+}
+
+func (node *llrbNode[K, V]) flip() {
+	node.red = !node.red
+	node.left.red = !node.left.red
+	node.right.red = !node.right.red
+}
+
+// Function fixUp restores left-leaning invariants of a node on the way up
+func (node *llrbNode[K, V]) fixUp() *llrbNode[K, V] {
+	if node.right.isRed() && !node.left.isRed() {
+		node = node.rotateLeft()
+	}
+	if node.left.isRed() && node.left.left.isRed() {
+		node = node.rotateRight()
+	}
+	if node.left.isRed() && node.right.isRed() {
+		node.flip()
+	}
+	return node
+}
+
+// Function moveRedLeft makes the left child or one of its children red, assuming the node is red
+func (node *llrbNode[K, V]) moveRedLeft() *llrbNode[K, V] {
+	node.flip()
+	if node.right.left.isRed() {
+		node.right = node.right.rotateRight()
+		node = node.rotateLeft()
+		node.flip()
+	}
+	return node
+}
+
+// Function moveRedRight makes the right child or one of its children red, assuming the node is red
+func (node *llrbNode[K, V]) moveRedRight() *llrbNode[K, V] {
+	node.flip()
+	if node.left.left.isRed() {
+		node = node.rotateRight()
+		node.flip()
+	}
+	return node
+}
+
+// Function removeMin detaches the node with the smallest key of a subtree, returning the rest of the subtree and the node
+func (node *llrbNode[K, V]) removeMin() (*llrbNode[K, V], *llrbNode[K, V]) {
+	if node.left == nil {
+		return nil, node
+	}
+	if !node.left.isRed() && !node.left.left.isRed() {
+		node = node.moveRedLeft()
+	}
+
+	var min *llrbNode[K, V]
+	node.left, min = node.left.removeMin()
+	return node.fixUp(), min
+}
+
+/*
+LLRBTree is an OrderedMap backed by a left-leaning Red-Black tree by Robert Sedgewick.
+Red links lean left only, which makes it an isometry of a 2-3 tree with much simpler code,
+at the cost of more rotations than in RedBlackTree.
+*/
+type LLRBTree[K constraints.Ordered, V any] struct {
+	root *llrbNode[K, V]
+	size int
+}
+
+// Function MakeLLRB creates empty instance of a left-leaning Red-Black tree
+func MakeLLRB[K constraints.Ordered, V any]() *LLRBTree[K, V] {
+	return &LLRBTree[K, V]{}
+}
+
+// Function Insert puts a value into a tree. If key already exists - function updates it's value
+func (tree *LLRBTree[K, V]) Insert(k K, v V) {
+	tree.root = tree.insert(tree.root, k, v)
+	tree.root.red = false
+}
+
+func (tree *LLRBTree[K, V]) insert(node *llrbNode[K, V], k K, v V) *llrbNode[K, V] {
+	if node == nil {
+		tree.size++
+		return &llrbNode[K, V]{key: k, value: v, red: true}
+	}
+
+	switch {
+	case k < node.key:
+		node.left = tree.insert(node.left, k, v)
+	case k > node.key:
+		node.right = tree.insert(node.right, k, v)
+	default:
+		node.value = v
+		return node
+	}
+	return node.fixUp()
+}
+
+// Function Search performs lookup of a value by key. Returns whether key was found
+func (tree *LLRBTree[K, V]) Search(k K) (value V, exists bool) {
+	if n := tree.search(k); n != nil {
+		return n.value, true
+	}
+	return
+}
+
+func (tree *LLRBTree[K, V]) search(k K) *llrbNode[K, V] {
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return nil
+}
+
+// Function Remove removes given key from a tree
+func (tree *LLRBTree[K, V]) Remove(k K) {
+	// Removal relies on the key being present
+	if tree.search(k) == nil {
+		return
+	}
+
+	if !tree.root.left.isRed() && !tree.root.right.isRed() {
+		tree.root.red = true
+	}
+	tree.root = tree.remove(tree.root, k)
+	if tree.root != nil {
+		tree.root.red = false
+	}
+	tree.size--
+}
+
+func (tree *LLRBTree[K, V]) remove(node *llrbNode[K, V], k K) *llrbNode[K, V] {
+	if k < node.key {
+		if !node.left.isRed() && !node.left.left.isRed() {
+			node = node.moveRedLeft()
+		}
+		node.left = tree.remove(node.left, k)
+		return node.fixUp()
+	}
+
+	if node.left.isRed() {
+		node = node.rotateRight()
+	}
+	if k == node.key && node.right == nil {
+		return nil
+	}
+	if !node.right.isRed() && !node.right.left.isRed() {
+		node = node.moveRedRight()
+	}
+
+	if k == node.key {
+		// Successor takes the place of the node
+		right, successor := node.right.removeMin()
+		successor.left, successor.right, successor.red = node.left, right, node.red
+		node = successor
+	} else {
+		node.right = tree.remove(node.right, k)
+	}
+	return node.fixUp()
+}
+
+// Function Keys returns a collection of keys in ascending order
+func (tree *LLRBTree[K, V]) Keys() []K {
+	result := make([]K, 0, tree.size)
+	tree.Traverse(func(k K, v V) {
+		result = append(result, k)
+	})
+	return result
+}
+
+// Function Traverse applies a closure to every entry in ascending key order
+func (tree *LLRBTree[K, V]) Traverse(closure func(k K, v V)) {
+	var stack []*llrbNode[K, V]
+	for n := tree.root; n != nil || len(stack) > 0; {
+		if n != nil {
+			stack = append(stack, n)
+			n = n.left
+			continue
+		}
+
+		n = stack[len(stack)-1]
+		stack = stack[:len(stack)-1]
+		closure(n.key, n.value)
+		n = n.right
+	}
+}
+
+// Function Size returns a number of elements stored in a tree
+func (tree *LLRBTree[K, V]) Size() int {
+	return tree.size
+}
+
+// Function Min returns an entry with the smallest key. Returns false if tree is empty
+func (tree *LLRBTree[K, V]) Min() (K, V, bool) {
+	n := tree.root
+	for n != nil && n.left != nil {
+		n = n.left
+	}
+	return n.entry()
+}
+
+// Function Max returns an entry with the largest key. Returns false if tree is empty
+func (tree *LLRBTree[K, V]) Max() (K, V, bool) {
+	n := tree.root
+	for n != nil && n.right != nil {
+		n = n.right
+	}
+	return n.entry()
+}
+
+// Function Floor returns an entry with the largest key less than or equal to k. Returns false if there is none
+func (tree *LLRBTree[K, V]) Floor(k K) (K, V, bool) {
+	var result *llrbNode[K, V]
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			result, n = n, n.right
+		default:
+			return n.entry()
+		}
+	}
+	return result.entry()
+}
+
+// Function Ceiling returns an entry with the smallest key greater than or equal to k. Returns false if there is none
+func (tree *LLRBTree[K, V]) Ceiling(k K) (K, V, bool) {
+	var result *llrbNode[K, V]
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			result, n = n, n.left
+		case k > n.key:
+			n = n.right
+		default:
+			return n.entry()
+		}
+	}
+	return result.entry()
+}
+
+func (node *llrbNode[K, V]) entry() (k K, v V, found bool) {
+	if node == nil {
+		return
+	}
+	return node.key, node.value, true
+}
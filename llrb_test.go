@@ -0,0 +1,52 @@
+package rbt
+
+import (
+	"fmt"
+	"testing"
+)
+
+// Function check verifies order of keys and left-leaning Red-Black properties of a subtree, returning its black height
+func (node *llrbNode[K, V]) check(minKey, maxKey *K) (int, error) {
+	if node == nil {
+		return 0, nil
+	}
+	if (minKey != nil && !(node.key > *minKey)) || (maxKey != nil && !(node.key < *maxKey)) {
+		return 0, fmt.Errorf("key %v is out of order", node.key)
+	}
+	if node.right.isRed() {
+		return 0, fmt.Errorf("right link of %v is red", node.key)
+	}
+	if node.red && node.left.isRed() {
+		return 0, fmt.Errorf("%v and its left child are red", node.key)
+	}
+
+	left, err := node.left.check(minKey, &node.key)
+	if err != nil {
+		return 0, err
+	}
+	right, err := node.right.check(&node.key, maxKey)
+	if err != nil {
+		return 0, err
+	}
+	if left != right {
+		return 0, fmt.Errorf("subtrees of %v have different black heights", node.key)
+	}
+
+	if !node.red {
+		left++
+	}
+	return left, nil
+}
+
+func TestOrderedMapLLRBTree(t *testing.T) {
+	runOrderedMapSuite(t,
+		func() OrderedMap[int, int] { return MakeLLRB[int, int]() },
+		func(m OrderedMap[int, int]) error {
+			root := m.(*LLRBTree[int, int]).root
+			if root.isRed() {
+				return fmt.Errorf("root is red")
+			}
+			_, err := root.check(nil, nil)
+			return err
+		})
+}
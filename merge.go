@@ -0,0 +1,72 @@
+package rbt
+
+/*
+Function Merge puts all entries of other tree into a tree in O(n+m), merging two sorted sequences
+and building a balanced tree of the result, instead of inserting entries one by one.
+
+Resolve is called only for keys present in both trees, with values of the tree and of other tree,
+and its result is stored. Nil resolve means that values of other tree win.
+Nodes of the tree are reused, so its handles stay valid. Other tree is not modified.
+Observers see new keys as insertions and collisions as updates.
+*/
+func (tree *RedBlackTree[K, V]) Merge(other *RedBlackTree[K, V], resolve func(k K, a, b V) V) {
+	if other.size == 0 {
+		return
+	}
+	if resolve == nil {
+		resolve = func(k K, a, b V) V { return b }
+	}
+
+	// Notifications are collected in key order and sent once the tree is consistent
+	type change struct {
+		node    *Node[K, V]
+		old     V
+		updated bool
+	}
+	var (
+		changes  []change
+		observed = len(tree.observers) > 0
+	)
+
+	mine := make([]*Node[K, V], 0, tree.size)
+	tree.root.inorder(func(n *Node[K, V]) {
+		mine = append(mine, n)
+	})
+
+	nodes := make([]*Node[K, V], 0, tree.size+other.size)
+	i := 0
+	for theirs := other.First(); theirs != nil; theirs = theirs.Next() {
+		for i < len(mine) && mine[i].key < theirs.key {
+			nodes = append(nodes, mine[i])
+			i++
+		}
+
+		if i < len(mine) && mine[i].key == theirs.key {
+			n := mine[i]
+			if observed {
+				changes = append(changes, change{node: n, old: n.value, updated: true})
+			}
+			n.value = resolve(n.key, n.value, theirs.value)
+			nodes = append(nodes, n)
+			i++
+			continue
+		}
+
+		n := tree.newNode(theirs.key, theirs.value)
+		if observed {
+			changes = append(changes, change{node: n})
+		}
+		nodes = append(nodes, n)
+	}
+	nodes = append(nodes, mine[i:]...)
+
+	tree.relink(nodes)
+
+	for _, c := range changes {
+		if c.updated {
+			tree.notifyUpdate(c.node.key, c.old, c.node.value)
+		} else {
+			tree.notifyInsert(c.node.key, c.node.value)
+		}
+	}
+}
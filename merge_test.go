@@ -0,0 +1,156 @@
+package rbt
+
+import (
+	"fmt"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type MergeSuite struct {
+	suite.Suite
+}
+
+func (suite *MergeSuite) TestMerge() {
+	a := makeDiffTree(map[int]string{1: "a", 3: "c", 5: "e"})
+	b := makeDiffTree(map[int]string{0: "z", 3: "C", 6: "f"})
+
+	var collisions []int
+	a.Merge(b, func(k int, x, y string) string {
+		collisions = append(collisions, k)
+		return x + y
+	})
+
+	assert.Equal(suite.T(), []int{3}, collisions)
+	assert.Equal(suite.T(), []int{0, 1, 3, 5, 6}, a.Keys())
+	v, _ := a.Search(3)
+	assert.Equal(suite.T(), "cC", v)
+	assert.NoError(suite.T(), a.Validate())
+
+	// Other tree is not modified
+	assert.Equal(suite.T(), []int{0, 3, 6}, b.Keys())
+	v, _ = b.Search(3)
+	assert.Equal(suite.T(), "C", v)
+	assert.NoError(suite.T(), b.Validate())
+}
+
+func (suite *MergeSuite) TestNilResolve() {
+	a := makeDiffTree(map[int]string{1: "a", 2: "b"})
+	a.Merge(makeDiffTree(map[int]string{2: "B"}), nil)
+
+	v, _ := a.Search(2)
+	assert.Equal(suite.T(), "B", v)
+}
+
+func (suite *MergeSuite) TestEmpty() {
+	a := Make[int, string]()
+	a.Merge(makeDiffTree(map[int]string{1: "a", 2: "b"}), nil)
+	assert.Equal(suite.T(), []int{1, 2}, a.Keys())
+	assert.NoError(suite.T(), a.Validate())
+
+	a.Merge(Make[int, string](), nil)
+	assert.Equal(suite.T(), []int{1, 2}, a.Keys())
+
+	a.Merge(a, func(k int, x, y string) string { return x + y })
+	v, _ := a.Search(1)
+	assert.Equal(suite.T(), "aa", v)
+	assert.Equal(suite.T(), 2, a.Size())
+}
+
+func (suite *MergeSuite) TestHandles() {
+	a := makeDiffTree(map[int]string{1: "a", 3: "c"})
+	handle := a.Handle(3)
+
+	a.Merge(makeDiffTree(map[int]string{2: "b", 3: "C", 4: "d"}), nil)
+
+	assert.Same(suite.T(), handle, a.Handle(3))
+	assert.Equal(suite.T(), "C", handle.Value())
+	assert.Equal(suite.T(), 4, handle.Next().Key())
+	assert.True(suite.T(), a.RemoveHandle(handle))
+	assert.Equal(suite.T(), []int{1, 2, 4}, a.Keys())
+}
+
+func (suite *MergeSuite) TestObservers() {
+	a := makeDiffTree(map[int]string{1: "a", 3: "c"})
+	var events []string
+	a.Observe(Observer[int, string]{
+		Insert: func(k int, v string) { events = append(events, fmt.Sprintf("insert %d %s", k, v)) },
+		Update: func(k int, old, new string) { events = append(events, fmt.Sprintf("update %d %s %s", k, old, new)) },
+		Remove: func(k int, v string) { events = append(events, fmt.Sprintf("remove %d %s", k, v)) },
+	})
+
+	a.Merge(makeDiffTree(map[int]string{0: "z", 3: "C", 4: "d"}), nil)
+	assert.Equal(suite.T(), []string{"insert 0 z", "update 3 c C", "insert 4 d"}, events)
+}
+
+func (suite *MergeSuite) TestAgainstInsert() {
+	rng := rand.New(rand.NewSource(1))
+	sum := func(k int, x, y int) int { return x + y }
+
+	for _, sizes := range [][2]int{{0, 100}, {100, 0}, {1000, 10}, {10, 1000}, {1000, 1000}} {
+		a, b, expected := MakePooled[int, int](), Make[int, int](), Make[int, int]()
+		for i := 0; i < sizes[0]; i++ {
+			k := rng.Intn(2000)
+			a.Insert(k, i)
+			expected.Insert(k, i)
+		}
+		// Pooled nodes are reused for new keys
+		a.Insert(-1, 0)
+		a.Remove(-1)
+		for i := 0; i < sizes[1]; i++ {
+			b.Insert(rng.Intn(2000), i)
+		}
+		b.Traverse(func(k, v int) {
+			if old, found := expected.Search(k); found {
+				v = sum(k, old, v)
+			}
+			expected.Insert(k, v)
+		})
+
+		a.Merge(b, sum)
+		assert.NoError(suite.T(), a.Validate())
+		if b.Size() > 0 {
+			assert.Equal(suite.T(), 0, a.Pooled())
+		}
+
+		count := 0
+		Diff(expected, a, func(x, y int) bool { return x == y }, func(Difference[int, int]) { count++ })
+		assert.Equal(suite.T(), 0, count, "sizes %v", sizes)
+	}
+}
+
+func TestMergeSuite(t *testing.T) {
+	suite.Run(t, new(MergeSuite))
+}
+
+func BenchmarkRedBlackTreeMerge(b *testing.B) {
+	for i := iMin; i <= iMax; i += 5 {
+		n := 1 << i
+		other := Make[int, int]()
+		for j := 0; j < n; j++ {
+			other.Insert(2*j+1, j)
+		}
+
+		b.Run(fmt.Sprintf("merge/size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tree := makeFilledTree(1, n)
+				b.StartTimer()
+				tree.Merge(other, nil)
+			}
+		})
+
+		b.Run(fmt.Sprintf("insert/size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				b.StopTimer()
+				tree := makeFilledTree(1, n)
+				b.StartTimer()
+				other.Traverse(tree.Insert)
+			}
+		})
+	}
+}
@@ -0,0 +1,56 @@
+package rbt
+
+/*
+Observer receives notifications about mutations of a tree, after they are applied.
+Any callback may be nil. Callbacks must not mutate the observed tree.
+*/
+type Observer[K any, V any] struct {
+	Insert func(k K, v V)        // New key is inserted
+	Update func(k K, old, new V) // Value of an existing key is replaced
+	Remove func(k K, v V)        // Key is removed
+}
+
+/*
+Function Observe registers an observer, which is notified about every following mutation.
+Observers are notified in the order of registration.
+It returns a function, which unregisters the observer.
+*/
+func (tree *RedBlackTree[K, V]) Observe(o Observer[K, V]) (cancel func()) {
+	registered := &o
+	tree.observers = append(tree.observers, registered)
+
+	return func() {
+		// Observers are copied, so that cancellation is safe during notification
+		observers := make([]*Observer[K, V], 0, len(tree.observers))
+		for _, o := range tree.observers {
+			if o != registered {
+				observers = append(observers, o)
+			}
+		}
+		tree.observers = observers
+	}
+}
+
+func (tree *RedBlackTree[K, V]) notifyInsert(k K, v V) {
+	for _, o := range tree.observers {
+		if o.Insert != nil {
+			o.Insert(k, v)
+		}
+	}
+}
+
+func (tree *RedBlackTree[K, V]) notifyUpdate(k K, old, new V) {
+	for _, o := range tree.observers {
+		if o.Update != nil {
+			o.Update(k, old, new)
+		}
+	}
+}
+
+func (tree *RedBlackTree[K, V]) notifyRemove(k K, v V) {
+	for _, o := range tree.observers {
+		if o.Remove != nil {
+			o.Remove(k, v)
+		}
+	}
+}
@@ -0,0 +1,132 @@
+package rbt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ObserverSuite struct {
+	suite.Suite
+}
+
+func recordingObserver(events *[]string) Observer[int, string] {
+	return Observer[int, string]{
+		Insert: func(k int, v string) {
+			*events = append(*events, fmt.Sprintf("insert %d=%s", k, v))
+		},
+		Update: func(k int, old, new string) {
+			*events = append(*events, fmt.Sprintf("update %d=%s->%s", k, old, new))
+		},
+		Remove: func(k int, v string) {
+			*events = append(*events, fmt.Sprintf("remove %d=%s", k, v))
+		},
+	}
+}
+
+func (suite *ObserverSuite) TestEvents() {
+	tree := Make[int, string]()
+	events := []string{}
+	tree.Observe(recordingObserver(&events))
+
+	tree.Insert(2, "a")
+	tree.Insert(1, "b")
+	tree.Insert(3, "c")
+	tree.Insert(2, "d")
+	// Removal of a node with two children moves successor payload into it
+	tree.Remove(2)
+	tree.Remove(42)
+
+	assert.Equal(suite.T(), []string{
+		"insert 2=a",
+		"insert 1=b",
+		"insert 3=c",
+		"update 2=a->d",
+		"remove 2=d",
+	}, events)
+}
+
+func (suite *ObserverSuite) TestSecondaryIndex() {
+	tree := Make[int, string]()
+	index := map[string]int{}
+	tree.Observe(Observer[int, string]{
+		Insert: func(k int, v string) { index[v] = k },
+		Update: func(k int, old, new string) {
+			delete(index, old)
+			index[new] = k
+		},
+		Remove: func(k int, v string) { delete(index, v) },
+	})
+
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, fmt.Sprint("v", i))
+	}
+	for i := 0; i < 100; i += 2 {
+		tree.Remove(i)
+	}
+	tree.Insert(1, "one")
+
+	assert.Len(suite.T(), index, 50)
+	assert.Equal(suite.T(), 1, index["one"])
+	tree.Traverse(func(k int, v string) {
+		assert.Equal(suite.T(), k, index[v])
+	})
+}
+
+func (suite *ObserverSuite) TestCancel() {
+	tree := Make[int, string]()
+	first, second := []string{}, []string{}
+	cancelFirst := tree.Observe(recordingObserver(&first))
+	tree.Observe(recordingObserver(&second))
+
+	tree.Insert(1, "a")
+	cancelFirst()
+	cancelFirst()
+	tree.Insert(2, "b")
+
+	assert.Equal(suite.T(), []string{"insert 1=a"}, first)
+	assert.Equal(suite.T(), []string{"insert 1=a", "insert 2=b"}, second)
+
+	// Observer may cancel itself during notification
+	var cancel func()
+	calls := 0
+	cancel = tree.Observe(Observer[int, string]{Insert: func(int, string) {
+		calls++
+		cancel()
+	}})
+	tree.Insert(3, "c")
+	tree.Insert(4, "d")
+	assert.Equal(suite.T(), 1, calls)
+}
+
+func (suite *ObserverSuite) TestPartialObserver() {
+	tree := Make[int, string]()
+	removed := []int{}
+	tree.Observe(Observer[int, string]{Remove: func(k int, v string) { removed = append(removed, k) }})
+
+	tree.Insert(1, "a")
+	tree.Insert(1, "b")
+	tree.Remove(1)
+	assert.Equal(suite.T(), []int{1}, removed)
+}
+
+func (suite *ObserverSuite) TestReplacement() {
+	tree := Make[int, string]()
+	tree.Insert(1, "a")
+
+	source := Make[int, string]()
+	source.Insert(2, "b")
+	data, err := source.MarshalBinary()
+	assert.NoError(suite.T(), err)
+
+	events := []string{}
+	tree.Observe(recordingObserver(&events))
+	assert.NoError(suite.T(), tree.UnmarshalBinary(data))
+	assert.Equal(suite.T(), []string{"remove 1=a", "insert 2=b"}, events)
+}
+
+func TestObserverSuite(t *testing.T) {
+	suite.Run(t, new(ObserverSuite))
+}
@@ -0,0 +1,150 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+/*
+OrderedMap is a map which keeps its keys in ascending order.
+It is implemented by RedBlackTree and by alternative backends, so call sites do not depend on a tree kind.
+*/
+type OrderedMap[K constraints.Ordered, V any] interface {
+	// Insert puts a value into a map. If key already exists - it updates its value
+	Insert(k K, v V)
+	// Search performs lookup of a value by key. Returns whether key was found
+	Search(k K) (V, bool)
+	// Remove removes given key from a map
+	Remove(k K)
+	// Keys returns a collection of keys in ascending order
+	Keys() []K
+	// Traverse applies a closure to every entry in ascending key order
+	Traverse(closure func(k K, v V))
+	// Size returns a number of entries
+	Size() int
+
+	// Min returns an entry with the smallest key. Returns false if a map is empty
+	Min() (K, V, bool)
+	// Max returns an entry with the largest key. Returns false if a map is empty
+	Max() (K, V, bool)
+	// Floor returns an entry with the largest key less than or equal to a given one
+	Floor(k K) (K, V, bool)
+	// Ceiling returns an entry with the smallest key greater than or equal to a given one
+	Ceiling(k K) (K, V, bool)
+}
+
+// Function Min returns an entry with the smallest key. Returns false if tree is empty
+func (tree *RedBlackTree[K, V]) Min() (k K, v V, found bool) {
+	return tree.First().entry()
+}
+
+// Function Max returns an entry with the largest key. Returns false if tree is empty
+func (tree *RedBlackTree[K, V]) Max() (k K, v V, found bool) {
+	return tree.Last().entry()
+}
+
+// Function Floor returns an entry with the largest key less than or equal to k. Returns false if there is none
+func (tree *RedBlackTree[K, V]) Floor(k K) (K, V, bool) {
+	return tree.floor(k).entry()
+}
+
+// Function Ceiling returns an entry with the smallest key greater than or equal to k. Returns false if there is none
+func (tree *RedBlackTree[K, V]) Ceiling(k K) (K, V, bool) {
+	return tree.ceiling(k).entry()
+}
+
+func (tree *RedBlackTree[K, V]) floor(k K) *Node[K, V] {
+	var result *Node[K, V]
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			n = n.left
+		case k > n.key:
+			result, n = n, n.right
+		default:
+			return n
+		}
+	}
+	return result
+}
+
+func (tree *RedBlackTree[K, V]) ceiling(k K) *Node[K, V] {
+	var result *Node[K, V]
+	for n := tree.root; n != nil; {
+		switch {
+		case k < n.key:
+			result, n = n, n.left
+		case k > n.key:
+			n = n.right
+		default:
+			return n
+		}
+	}
+	return result
+}
+
+// Function entry returns contents of a node, if there is one
+func (node *Node[K, V]) entry() (k K, v V, found bool) {
+	if node == nil {
+		return
+	}
+	return node.key, node.value, true
+}
+
+// Function Min returns an entry with the smallest key. Returns false if tree is empty
+func (at *ArenaTree[K, V]) Min() (K, V, bool) {
+	if at.root == arenaNil {
+		return at.entry(arenaNil)
+	}
+	return at.entry(at.minimum(at.root))
+}
+
+// Function Max returns an entry with the largest key. Returns false if tree is empty
+func (at *ArenaTree[K, V]) Max() (K, V, bool) {
+	x := at.root
+	for x != arenaNil && at.node(x).right != arenaNil {
+		x = at.node(x).right
+	}
+	return at.entry(x)
+}
+
+// Function Floor returns an entry with the largest key less than or equal to k. Returns false if there is none
+func (at *ArenaTree[K, V]) Floor(k K) (K, V, bool) {
+	result := arenaNil
+	for x := at.root; x != arenaNil; {
+		n := at.node(x)
+		switch {
+		case k < n.key:
+			x = n.left
+		case k > n.key:
+			result, x = x, n.right
+		default:
+			return at.entry(x)
+		}
+	}
+	return at.entry(result)
+}
+
+// Function Ceiling returns an entry with the smallest key greater than or equal to k. Returns false if there is none
+func (at *ArenaTree[K, V]) Ceiling(k K) (K, V, bool) {
+	result := arenaNil
+	for x := at.root; x != arenaNil; {
+		n := at.node(x)
+		switch {
+		case k < n.key:
+			result, x = x, n.left
+		case k > n.key:
+			x = n.right
+		default:
+			return at.entry(x)
+		}
+	}
+	return at.entry(result)
+}
+
+func (at *ArenaTree[K, V]) entry(i uint32) (k K, v V, found bool) {
+	if i == arenaNil {
+		return
+	}
+	n := at.node(i)
+	return n.key, n.value, true
+}
@@ -0,0 +1,267 @@
+package rbt
+
+import (
+	"errors"
+	"math/rand"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+	"golang.org/x/exp/slices"
+)
+
+var (
+	_ OrderedMap[int, int] = (*RedBlackTree[int, int])(nil)
+	_ OrderedMap[int, int] = (*ArenaTree[int, int])(nil)
+	_ OrderedMap[int, int] = (*AVLTree[int, int])(nil)
+	_ OrderedMap[int, int] = (*LLRBTree[int, int])(nil)
+	_ OrderedMap[int, int] = (*BTree[int, int])(nil)
+)
+
+/*
+OrderedMapSuite is a conformance suite, which every OrderedMap implementation has to pass.
+It is derived from RedBlackTreeSuite, with checks of tree internals replaced by a validate function.
+*/
+type OrderedMapSuite struct {
+	suite.Suite
+
+	make     func() OrderedMap[int, int]
+	validate func(m OrderedMap[int, int]) error // Checks invariants of a backend
+}
+
+func (suite *OrderedMapSuite) assertValid(m OrderedMap[int, int]) {
+	assert.NoError(suite.T(), suite.validate(m))
+	assert.Equal(suite.T(), len(m.Keys()), m.Size())
+}
+
+func (suite *OrderedMapSuite) TestCreateEmpty() {
+	m := suite.make()
+	assert.Equal(suite.T(), 0, m.Size())
+	assert.Empty(suite.T(), m.Keys())
+	suite.assertValid(m)
+
+	_, _, found := m.Min()
+	assert.False(suite.T(), found)
+	_, _, found = m.Max()
+	assert.False(suite.T(), found)
+	_, _, found = m.Floor(0)
+	assert.False(suite.T(), found)
+	_, _, found = m.Ceiling(0)
+	assert.False(suite.T(), found)
+}
+
+func (suite *OrderedMapSuite) TestInsert() {
+	m := suite.make()
+	for i, k := range []int{8, 18, 5, 15, 17, 25, 40, 80, 3, 1, -3, 60} {
+		m.Insert(k, k*10)
+		assert.Equal(suite.T(), i+1, m.Size())
+		suite.assertValid(m)
+	}
+	assert.Equal(suite.T(), []int{-3, 1, 3, 5, 8, 15, 17, 18, 25, 40, 60, 80}, m.Keys())
+}
+
+func (suite *OrderedMapSuite) TestUpdate() {
+	m := suite.make()
+
+	m.Insert(8, 1)
+	m.Insert(8, 2)
+	assert.Equal(suite.T(), 1, m.Size())
+
+	v, found := m.Search(8)
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), 2, v)
+}
+
+func (suite *OrderedMapSuite) TestSearch() {
+	data := []struct {
+		key   int
+		value int
+	}{{8, 1}, {18, 2}, {5, 3}, {15, 4}, {17, 5}, {25, 6}, {40, 7}, {80, 8}, {3, 9}, {1, 10}, {-3, 11}, {60, 12}}
+
+	m := suite.make()
+
+	_, exists := m.Search(8)
+	assert.False(suite.T(), exists)
+
+	for _, el := range data {
+		m.Insert(el.key, el.value)
+	}
+
+	for _, el := range data {
+		result, exists := m.Search(el.key)
+		assert.True(suite.T(), exists)
+		assert.Equal(suite.T(), el.value, result)
+	}
+
+	_, exists = m.Search(-99)
+	assert.False(suite.T(), exists)
+}
+
+func (suite *OrderedMapSuite) TestDelete() {
+	m := suite.make()
+	keys := []int{8, 18, 5, 15, 17, 25, 40, 80, 3, 1, -3, 60}
+	for _, k := range keys {
+		m.Insert(k, k)
+	}
+
+	// Removal of an absent key is a no-op
+	m.Remove(100)
+	assert.Equal(suite.T(), len(keys), m.Size())
+
+	for i, k := range keys {
+		m.Remove(k)
+		suite.assertValid(m)
+		assert.Equal(suite.T(), len(keys)-i-1, m.Size())
+
+		_, found := m.Search(k)
+		assert.False(suite.T(), found)
+	}
+}
+
+func (suite *OrderedMapSuite) TestTraversal() {
+	expected := []int{5, 8, 15, 17, 18, 25, 40, 60}
+	m := suite.make()
+
+	for _, e := range []int{60, 25, 17, 5, 40, 8, 15, 18} {
+		m.Insert(e, e*2)
+	}
+
+	assert.Equal(suite.T(), len(expected), m.Size())
+
+	actual := make([]int, 0, m.Size())
+	m.Traverse(func(k, v int) {
+		assert.Equal(suite.T(), k*2, v)
+		actual = append(actual, k)
+	})
+
+	assert.Equal(suite.T(), expected, actual)
+}
+
+func (suite *OrderedMapSuite) TestWithSyntheticValues() {
+	const n = 1000
+	m := suite.make()
+
+	for i := 0; i < n; i++ {
+		m.Insert(i, i)
+	}
+	for i := n * 2; i > n; i-- {
+		m.Insert(i, i)
+	}
+	assert.Equal(suite.T(), n*2, m.Size())
+	suite.assertValid(m)
+
+	for i := 0; i < n; i++ {
+		m.Remove(i)
+	}
+	for i := n * 2; i > n; i-- {
+		m.Remove(i)
+	}
+	assert.Equal(suite.T(), 0, m.Size())
+	suite.assertValid(m)
+}
+
+func (suite *OrderedMapSuite) TestOrderedQueries() {
+	m := suite.make()
+	for _, k := range []int{10, 20, 30, 40, 50} {
+		m.Insert(k, k*10)
+	}
+
+	k, v, found := m.Min()
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), [2]int{10, 100}, [2]int{k, v})
+
+	k, v, found = m.Max()
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), [2]int{50, 500}, [2]int{k, v})
+
+	floors := map[int]int{10: 10, 15: 10, 49: 40, 50: 50, 100: 50}
+	for q, expected := range floors {
+		k, v, found := m.Floor(q)
+		assert.True(suite.T(), found)
+		assert.Equal(suite.T(), [2]int{expected, expected * 10}, [2]int{k, v}, "floor of %d", q)
+	}
+	_, _, found = m.Floor(9)
+	assert.False(suite.T(), found)
+
+	ceilings := map[int]int{0: 10, 10: 10, 11: 20, 41: 50, 50: 50}
+	for q, expected := range ceilings {
+		k, v, found := m.Ceiling(q)
+		assert.True(suite.T(), found)
+		assert.Equal(suite.T(), [2]int{expected, expected * 10}, [2]int{k, v}, "ceiling of %d", q)
+	}
+	_, _, found = m.Ceiling(51)
+	assert.False(suite.T(), found)
+}
+
+func (suite *OrderedMapSuite) TestAgainstModel() {
+	rng := rand.New(rand.NewSource(1))
+	m := suite.make()
+	model := map[int]int{}
+
+	for i := 0; i < 20000; i++ {
+		k, v := rng.Intn(3000), rng.Int()
+		if rng.Intn(3) == 0 {
+			m.Remove(k)
+			delete(model, k)
+		} else {
+			m.Insert(k, v)
+			model[k] = v
+		}
+
+		if i%1000 == 0 {
+			suite.assertValid(m)
+		}
+	}
+	suite.assertValid(m)
+
+	keys := make([]int, 0, len(model))
+	for k := range model {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+	assert.Equal(suite.T(), keys, m.Keys())
+
+	m.Traverse(func(k, v int) {
+		assert.Equal(suite.T(), model[k], v, "value of %d", k)
+	})
+
+	for q := -1; q <= 3000; q++ {
+		i, exact := slices.BinarySearch(keys, q)
+
+		k, _, found := m.Ceiling(q)
+		assert.Equal(suite.T(), i < len(keys), found)
+		if found {
+			assert.Equal(suite.T(), keys[i], k)
+		}
+
+		if !exact {
+			i--
+		}
+		k, _, found = m.Floor(q)
+		assert.Equal(suite.T(), i >= 0, found)
+		if found {
+			assert.Equal(suite.T(), keys[i], k)
+		}
+	}
+}
+
+func runOrderedMapSuite(t *testing.T, make func() OrderedMap[int, int], validate func(OrderedMap[int, int]) error) {
+	suite.Run(t, &OrderedMapSuite{make: make, validate: validate})
+}
+
+func TestOrderedMapRedBlackTree(t *testing.T) {
+	runOrderedMapSuite(t,
+		func() OrderedMap[int, int] { return Make[int, int]() },
+		func(m OrderedMap[int, int]) error { return m.(*RedBlackTree[int, int]).Validate() })
+}
+
+func TestOrderedMapArenaTree(t *testing.T) {
+	runOrderedMapSuite(t,
+		func() OrderedMap[int, int] { return MakeArena[int, int]() },
+		func(m OrderedMap[int, int]) error {
+			if !m.(*ArenaTree[int, int]).isValid() {
+				return errors.New("invalid arena tree")
+			}
+			return nil
+		})
+}
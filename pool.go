@@ -0,0 +1,50 @@
+package rbt
+
+import (
+	"golang.org/x/exp/constraints"
+)
+
+/*
+Function MakePooled creates empty instance of a tree, which recycles nodes of removed entries
+for following insertions instead of leaving them to the garbage collector.
+Pooled nodes are kept in a per-tree free list until Shrink is called.
+
+A handle of a removed entry may be reused for another entry, so handles must not be kept past removal.
+*/
+func MakePooled[K constraints.Ordered, V any]() *RedBlackTree[K, V] {
+	return &RedBlackTree[K, V]{pooling: true}
+}
+
+// Function Pooled returns a number of removed nodes kept for reuse
+func (tree *RedBlackTree[K, V]) Pooled() int {
+	return tree.pooled
+}
+
+// Function Shrink releases all pooled nodes to the garbage collector
+func (tree *RedBlackTree[K, V]) Shrink() {
+	tree.pool, tree.pooled = nil, 0
+}
+
+// Function newNode takes a node from the pool if there is one, otherwise allocates it
+func (tree *RedBlackTree[K, V]) newNode(k K, v V) *Node[K, V] {
+	n := tree.pool
+	if n == nil {
+		return MakeNode(k, v, red)
+	}
+
+	tree.pool = n.right
+	tree.pooled--
+	n.key, n.value, n.color, n.right = k, v, red, nil
+	return n
+}
+
+// Function recycle puts a removed node into the pool, dropping references held by its key and value
+func (tree *RedBlackTree[K, V]) recycle(n *Node[K, V]) {
+	if !tree.pooling {
+		return
+	}
+
+	*n = Node[K, V]{right: tree.pool}
+	tree.pool = n
+	tree.pooled++
+}
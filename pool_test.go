@@ -0,0 +1,127 @@
+package rbt
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PoolSuite struct {
+	suite.Suite
+}
+
+func (suite *PoolSuite) TestReuse() {
+	tree := MakePooled[int, *int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, new(int))
+	}
+
+	removed := tree.Handle(3)
+	tree.Remove(3)
+	tree.Remove(3)
+	assert.Equal(suite.T(), 1, tree.Pooled())
+	assert.Nil(suite.T(), removed.Value())
+
+	tree.Insert(42, nil)
+	assert.Equal(suite.T(), 0, tree.Pooled())
+	assert.Same(suite.T(), removed, tree.Handle(42))
+	assert.True(suite.T(), tree.isValidRBTree())
+	assert.Equal(suite.T(), []int{0, 1, 2, 4, 5, 6, 7, 8, 9, 42}, tree.Keys())
+}
+
+func (suite *PoolSuite) TestUpdateDoesNotAllocate() {
+	tree := MakePooled[int, int]()
+	tree.Insert(1, 1)
+
+	allocs := testing.AllocsPerRun(100, func() {
+		tree.Insert(1, 2)
+	})
+	assert.Zero(suite.T(), allocs)
+}
+
+func (suite *PoolSuite) TestChurn() {
+	tree := MakePooled[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+
+	allocs := testing.AllocsPerRun(100, func() {
+		for i := 0; i < 100; i += 3 {
+			tree.Remove(i)
+		}
+		for i := 0; i < 100; i += 3 {
+			tree.Insert(i, i)
+		}
+	})
+	assert.Zero(suite.T(), allocs)
+	assert.Equal(suite.T(), 100, tree.Size())
+	assert.True(suite.T(), tree.isValidRBTree())
+}
+
+func (suite *PoolSuite) TestRemoveHandle() {
+	tree := MakePooled[int, int]()
+	tree.Insert(1, 1)
+	tree.Insert(2, 2)
+
+	assert.True(suite.T(), tree.RemoveHandle(tree.Handle(1)))
+	assert.Equal(suite.T(), 1, tree.Pooled())
+	assert.True(suite.T(), tree.isValidRBTree())
+}
+
+func (suite *PoolSuite) TestShrink() {
+	tree := MakePooled[int, int]()
+	for i := 0; i < 10; i++ {
+		tree.Insert(i, i)
+	}
+	for i := 0; i < 10; i++ {
+		tree.Remove(i)
+	}
+	assert.Equal(suite.T(), 10, tree.Pooled())
+
+	tree.Shrink()
+	assert.Equal(suite.T(), 0, tree.Pooled())
+	assert.Nil(suite.T(), tree.pool)
+
+	tree.Insert(1, 1)
+	assert.Equal(suite.T(), []int{1}, tree.Keys())
+}
+
+func (suite *PoolSuite) TestNotPooledByDefault() {
+	tree := Make[int, int]()
+	tree.Insert(1, 1)
+	tree.Remove(1)
+	assert.Equal(suite.T(), 0, tree.Pooled())
+}
+
+func TestPoolSuite(t *testing.T) {
+	suite.Run(t, new(PoolSuite))
+}
+
+func BenchmarkRedBlackTreeChurn(b *testing.B) {
+	trees := map[string]func() *RedBlackTree[int, int]{
+		"plain":  Make[int, int],
+		"pooled": MakePooled[int, int],
+	}
+
+	for _, name := range []string{"plain", "pooled"} {
+		for i := iMin; i <= iMax; i += 5 {
+			n := 1 << i
+			b.Run(fmt.Sprintf("%s/size_%d", name, n), func(b *testing.B) {
+				tree := trees[name]()
+				for j := 0; j < n; j++ {
+					tree.Insert(j, 0)
+				}
+				b.ReportAllocs()
+				b.ResetTimer()
+
+				for i := 0; i < b.N; i++ {
+					k := i % n
+					tree.Remove(k)
+					tree.Insert(k, i)
+				}
+			})
+		}
+	}
+}
@@ -0,0 +1,49 @@
+package rbt
+
+import (
+	"strings"
+)
+
+/*
+Function WithPrefix applies a closure to every entry, which key starts with a given prefix, in ascending key order.
+Keys sharing a prefix are adjacent in lexicographic order, so it takes O(log n + k) for k matching entries.
+*/
+func WithPrefix[K ~string, V any](tree *RedBlackTree[K, V], prefix K, closure func(k K, v V)) {
+	for n := tree.ceiling(prefix); n != nil && strings.HasPrefix(string(n.key), string(prefix)); n = n.Next() {
+		closure(n.key, n.value)
+	}
+}
+
+// Function CountPrefix returns a number of keys, which start with a given prefix, in O(log n + k)
+func CountPrefix[K ~string, V any](tree *RedBlackTree[K, V], prefix K) int {
+	count := 0
+	for n := tree.ceiling(prefix); n != nil && strings.HasPrefix(string(n.key), string(prefix)); n = n.Next() {
+		count++
+	}
+	return count
+}
+
+/*
+Function LongestPrefixOf returns an entry with the longest key, which is a prefix of s. Returns false if there is none.
+
+Every key that is a prefix of s is not greater than s, so the answer is the floor of s, if it is a prefix.
+Otherwise no key longer than the common prefix of s and the floor can be the answer,
+so the search continues with that common prefix, which is strictly shorter than s.
+*/
+func LongestPrefixOf[K ~string, V any](tree *RedBlackTree[K, V], s K) (k K, v V, found bool) {
+	for {
+		n := tree.floor(s)
+		if n == nil {
+			return
+		}
+		if strings.HasPrefix(string(s), string(n.key)) {
+			return n.key, n.value, true
+		}
+
+		common := 0
+		for common < len(s) && common < len(n.key) && s[common] == n.key[common] {
+			common++
+		}
+		s = s[:common]
+	}
+}
@@ -0,0 +1,130 @@
+package rbt
+
+import (
+	"math/rand"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrefixSuite struct {
+	suite.Suite
+}
+
+func makePathTree() *RedBlackTree[string, int] {
+	tree := Make[string, int]()
+	for i, k := range []string{
+		"tenant",
+		"tenant/a",
+		"tenant/a/bucket",
+		"tenant/a/bucket/object1",
+		"tenant/a/bucket/object2",
+		"tenant/a/bucket2/object",
+		"tenant/b/bucket/object",
+		"tenant0",
+		"other/bucket",
+	} {
+		tree.Insert(k, i)
+	}
+	return tree
+}
+
+func (suite *PrefixSuite) TestWithPrefix() {
+	tree := makePathTree()
+	collect := func(prefix string) []string {
+		var keys []string
+		WithPrefix(tree, prefix, func(k string, v int) {
+			keys = append(keys, k)
+		})
+		return keys
+	}
+
+	assert.Equal(suite.T(), []string{
+		"tenant/a/bucket",
+		"tenant/a/bucket/object1",
+		"tenant/a/bucket/object2",
+		"tenant/a/bucket2/object",
+	}, collect("tenant/a/bucket"))
+	assert.Equal(suite.T(), []string{"tenant/a/bucket/object1", "tenant/a/bucket/object2"}, collect("tenant/a/bucket/"))
+	assert.Equal(suite.T(), []string{"other/bucket"}, collect("o"))
+	assert.Empty(suite.T(), collect("tenant/c"))
+	assert.Empty(suite.T(), collect("zzz"))
+	assert.Equal(suite.T(), tree.Keys(), collect(""))
+
+	assert.Equal(suite.T(), 4, CountPrefix(tree, "tenant/a/bucket"))
+	assert.Equal(suite.T(), 8, CountPrefix(tree, "tenant"))
+	assert.Equal(suite.T(), 0, CountPrefix(tree, "tenant/c"))
+	assert.Equal(suite.T(), tree.Size(), CountPrefix(tree, ""))
+}
+
+func (suite *PrefixSuite) TestLongestPrefixOf() {
+	tree := makePathTree()
+	longest := func(s string) string {
+		k, v, found := LongestPrefixOf(tree, s)
+		if !found {
+			return "<none>"
+		}
+		expected, _ := tree.Search(k)
+		assert.Equal(suite.T(), expected, v)
+		return k
+	}
+
+	assert.Equal(suite.T(), "tenant/a/bucket/object1", longest("tenant/a/bucket/object1"))
+	assert.Equal(suite.T(), "tenant/a/bucket", longest("tenant/a/bucket/object3"))
+	assert.Equal(suite.T(), "tenant/a/bucket", longest("tenant/a/bucket1"))
+	assert.Equal(suite.T(), "tenant/a", longest("tenant/a/bucker"))
+	assert.Equal(suite.T(), "tenant", longest("tenant/c/bucket"))
+	assert.Equal(suite.T(), "tenant", longest("tenant1"))
+	assert.Equal(suite.T(), "<none>", longest("tenan"))
+	assert.Equal(suite.T(), "<none>", longest("aaa"))
+	assert.Equal(suite.T(), "<none>", longest(""))
+
+	tree.Insert("", -1)
+	assert.Equal(suite.T(), "", longest("aaa"))
+}
+
+type objectPath string
+
+func (suite *PrefixSuite) TestAgainstScan() {
+	rng := rand.New(rand.NewSource(1))
+	word := func() objectPath {
+		b := make([]byte, rng.Intn(6))
+		for i := range b {
+			b[i] = "ab/"[rng.Intn(3)]
+		}
+		return objectPath(b)
+	}
+
+	tree := Make[objectPath, int]()
+	for i := 0; i < 300; i++ {
+		tree.Insert(word(), i)
+	}
+	keys := tree.Keys()
+
+	for i := 0; i < 500; i++ {
+		q := word()
+
+		count := 0
+		var best objectPath
+		found := false
+		for _, k := range keys {
+			if strings.HasPrefix(string(k), string(q)) {
+				count++
+			}
+			if strings.HasPrefix(string(q), string(k)) && (!found || len(k) > len(best)) {
+				best, found = k, true
+			}
+		}
+
+		assert.Equal(suite.T(), count, CountPrefix(tree, q), "count of %q", q)
+		k, _, ok := LongestPrefixOf(tree, q)
+		assert.Equal(suite.T(), found, ok, "longest prefix of %q", q)
+		assert.Equal(suite.T(), best, k, "longest prefix of %q", q)
+	}
+}
+
+func TestPrefixSuite(t *testing.T) {
+	suite.Run(t, new(PrefixSuite))
+}
@@ -0,0 +1,62 @@
+package rbt
+
+import (
+	"bufio"
+	"io"
+	"strings"
+)
+
+/*
+Function Fprint writes a tree sideways in plain text, one node per line, using Node.String.
+The root is printed at the left edge, right subtrees are printed above their parents
+and left subtrees below, so the tree reads as rotated 90 degrees counterclockwise:
+
+	|       /-- 4(RED)
+	|   /-- 3(BLACK)
+	\-- 2(BLACK)
+	    \-- 1(BLACK)
+
+Nothing is written for an empty tree.
+*/
+func (tree *RedBlackTree[K, V]) Fprint(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	if tree.root != nil {
+		tree.root.fprint(bw, "", true)
+	}
+	return bw.Flush()
+}
+
+// Function Pretty returns a tree rendered as by Fprint
+func (tree *RedBlackTree[K, V]) Pretty() string {
+	var sb strings.Builder
+	tree.Fprint(&sb)
+	return sb.String()
+}
+
+// Tail node is the one printed below its parent, which is true for left children and the root
+func (node *Node[K, V]) fprint(w *bufio.Writer, prefix string, tail bool) {
+	if node.right != nil {
+		if tail {
+			node.right.fprint(w, prefix+"|   ", false)
+		} else {
+			node.right.fprint(w, prefix+"    ", false)
+		}
+	}
+
+	w.WriteString(prefix)
+	if tail {
+		w.WriteString("\\-- ")
+	} else {
+		w.WriteString("/-- ")
+	}
+	w.WriteString(node.String())
+	w.WriteByte('\n')
+
+	if node.left != nil {
+		if tail {
+			node.left.fprint(w, prefix+"    ", true)
+		} else {
+			node.left.fprint(w, prefix+"|   ", true)
+		}
+	}
+}
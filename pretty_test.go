@@ -0,0 +1,69 @@
+package rbt
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PrettySuite struct {
+	suite.Suite
+}
+
+func (suite *PrettySuite) TestEmpty() {
+	assert.Equal(suite.T(), "", Make[int, int]().Pretty())
+}
+
+func (suite *PrettySuite) TestSmall() {
+	tree := Make[int, int]()
+	for _, k := range []int{1, 2, 3, 4} {
+		tree.Insert(k, k)
+	}
+
+	assert.Equal(suite.T(), `|       /-- 4(RED)
+|   /-- 3(BLACK)
+\-- 2(BLACK)
+    \-- 1(BLACK)
+`, tree.Pretty())
+}
+
+func (suite *PrettySuite) TestGolden() {
+	tree := Make[int, int]()
+	for _, k := range []int{8, 18, 5, 15, 17, 25, 40, 80, 3, 1, -3, 60} {
+		tree.Insert(k, k)
+	}
+
+	// Same shape as drawn top-down in TestInsert
+	assert.Equal(suite.T(), `|           /-- 80(RED)
+|       /-- 60(BLACK)
+|       |   \-- 40(RED)
+|   /-- 25(BLACK)
+|   |   \-- 18(BLACK)
+\-- 17(BLACK)
+    |   /-- 15(BLACK)
+    \-- 8(BLACK)
+        |   /-- 5(BLACK)
+        \-- 3(RED)
+            \-- 1(BLACK)
+                \-- -3(RED)
+`, tree.Pretty())
+}
+
+func (suite *PrettySuite) TestFprint() {
+	tree := Make[string, int]()
+	tree.Insert("b", 0)
+	tree.Insert("a", 0)
+
+	var sb strings.Builder
+	assert.NoError(suite.T(), tree.Fprint(&sb))
+	assert.Equal(suite.T(), tree.Pretty(), sb.String())
+	assert.Equal(suite.T(), "\\-- b(BLACK)\n    \\-- a(RED)\n", sb.String())
+
+	assert.Error(suite.T(), tree.Fprint(failingWriter{}))
+}
+
+func TestPrettySuite(t *testing.T) {
+	suite.Run(t, new(PrettySuite))
+}
@@ -0,0 +1,194 @@
+package rbt
+
+import (
+	"container/list"
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+type queueItem[P constraints.Ordered, T comparable] struct {
+	item     T
+	priority P
+}
+
+/*
+PriorityQueue is a queue of distinct items ordered by priority, smallest first.
+Items of equal priority are dequeued in the order they were pushed.
+
+Unlike a binary heap, it allows to change a priority of any item and to remove any item in O(log n):
+every priority is a key of a tree, which holds a list of items, and items are indexed by a map.
+*/
+type PriorityQueue[P constraints.Ordered, T comparable] struct {
+	tree  *RedBlackTree[P, *list.List]
+	items map[T]*list.Element
+}
+
+// Function MakePriorityQueue creates empty instance of a priority queue
+func MakePriorityQueue[P constraints.Ordered, T comparable]() *PriorityQueue[P, T] {
+	return &PriorityQueue[P, T]{
+		tree:  Make[P, *list.List](),
+		items: make(map[T]*list.Element),
+	}
+}
+
+// Function Push adds an item with a given priority. If item is already queued - function updates it's priority
+func (q *PriorityQueue[P, T]) Push(item T, priority P) {
+	if e, found := q.items[item]; found {
+		q.unlink(e)
+	}
+
+	bucket, found := q.tree.Search(priority)
+	if !found {
+		bucket = list.New()
+		q.tree.Insert(priority, bucket)
+	}
+	q.items[item] = bucket.PushBack(queueItem[P, T]{item: item, priority: priority})
+}
+
+// Function unlink removes an element from its bucket, and the bucket from a tree if it gets empty
+func (q *PriorityQueue[P, T]) unlink(e *list.Element) queueItem[P, T] {
+	qi := e.Value.(queueItem[P, T])
+	delete(q.items, qi.item)
+
+	bucket, _ := q.tree.Search(qi.priority)
+	bucket.Remove(e)
+	if bucket.Len() == 0 {
+		q.tree.Remove(qi.priority)
+	}
+	return qi
+}
+
+// Function front returns the element to be dequeued next, or nil if queue is empty
+func (q *PriorityQueue[P, T]) front() *list.Element {
+	if n := q.tree.First(); n != nil {
+		return n.value.Front()
+	}
+	return nil
+}
+
+// Function Peek returns an item of the smallest priority without removing it. Returns false if queue is empty
+func (q *PriorityQueue[P, T]) Peek() (item T, priority P, found bool) {
+	if e := q.front(); e != nil {
+		qi := e.Value.(queueItem[P, T])
+		return qi.item, qi.priority, true
+	}
+	return
+}
+
+// Function Pop removes and returns an item of the smallest priority. Returns false if queue is empty
+func (q *PriorityQueue[P, T]) Pop() (item T, priority P, found bool) {
+	if e := q.front(); e != nil {
+		qi := q.unlink(e)
+		return qi.item, qi.priority, true
+	}
+	return
+}
+
+/*
+Function UpdatePriority changes a priority of a queued item, placing it after items already having that priority.
+Returns false if item is not queued.
+*/
+func (q *PriorityQueue[P, T]) UpdatePriority(item T, priority P) bool {
+	if _, found := q.items[item]; !found {
+		return false
+	}
+
+	q.Push(item, priority)
+	return true
+}
+
+// Function Remove removes an item from a queue. Returns false if item is not queued
+func (q *PriorityQueue[P, T]) Remove(item T) bool {
+	e, found := q.items[item]
+	if found {
+		q.unlink(e)
+	}
+	return found
+}
+
+// Function Priority returns a priority of a queued item. Returns false if item is not queued
+func (q *PriorityQueue[P, T]) Priority(item T) (priority P, found bool) {
+	if e, found := q.items[item]; found {
+		return e.Value.(queueItem[P, T]).priority, true
+	}
+	return
+}
+
+// Function Size returns a number of queued items
+func (q *PriorityQueue[P, T]) Size() int {
+	return len(q.items)
+}
+
+/*
+Scheduler holds items until their scheduled time, e.g. delayed jobs.
+It does not run goroutines or timers: due items are taken by Fire, and Next tells when to call it.
+*/
+type Scheduler[T comparable] struct {
+	queue *PriorityQueue[int64, T] // Items by scheduled Unix time in nanoseconds
+	now   func() time.Time
+}
+
+/*
+Function MakeScheduler creates empty instance of a scheduler.
+It takes a clock, which is used to find due items. Nil clock means time.Now.
+*/
+func MakeScheduler[T comparable](now func() time.Time) *Scheduler[T] {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &Scheduler[T]{queue: MakePriorityQueue[int64, T](), now: now}
+}
+
+// Function Schedule schedules an item at a given time. If item is already scheduled - function reschedules it
+func (s *Scheduler[T]) Schedule(item T, at time.Time) {
+	s.queue.Push(item, at.UnixNano())
+}
+
+// Function After schedules an item after a given delay. If item is already scheduled - function reschedules it
+func (s *Scheduler[T]) After(item T, delay time.Duration) {
+	s.Schedule(item, s.now().Add(delay))
+}
+
+// Function Cancel removes a scheduled item. Returns false if item is not scheduled
+func (s *Scheduler[T]) Cancel(item T) bool {
+	return s.queue.Remove(item)
+}
+
+// Function Next returns the earliest scheduled time. Returns false if nothing is scheduled
+func (s *Scheduler[T]) Next() (time.Time, bool) {
+	_, at, found := s.queue.Peek()
+	if !found {
+		return time.Time{}, false
+	}
+	return time.Unix(0, at), true
+}
+
+/*
+Function Fire removes items, which scheduled time has passed, and applies a closure to them in order of their time.
+Closure may schedule items again, which are fired on the next call only. Returns a number of fired items.
+*/
+func (s *Scheduler[T]) Fire(closure func(item T)) int {
+	now := s.now().UnixNano()
+
+	var due []T
+	for {
+		item, at, found := s.queue.Peek()
+		if !found || at > now {
+			break
+		}
+		s.queue.Pop()
+		due = append(due, item)
+	}
+
+	for _, item := range due {
+		closure(item)
+	}
+	return len(due)
+}
+
+// Function Size returns a number of scheduled items
+func (s *Scheduler[T]) Size() int {
+	return s.queue.Size()
+}
@@ -0,0 +1,186 @@
+package rbt
+
+import (
+	"math/rand"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type PriorityQueueSuite struct {
+	suite.Suite
+}
+
+func (suite *PriorityQueueSuite) popAll(q *PriorityQueue[int, string]) []string {
+	var items []string
+	for {
+		item, _, found := q.Pop()
+		if !found {
+			return items
+		}
+		items = append(items, item)
+	}
+}
+
+func (suite *PriorityQueueSuite) TestOrder() {
+	q := MakePriorityQueue[int, string]()
+	_, _, found := q.Peek()
+	assert.False(suite.T(), found)
+
+	q.Push("c", 3)
+	q.Push("a1", 1)
+	q.Push("b", 2)
+	q.Push("a2", 1)
+	assert.Equal(suite.T(), 4, q.Size())
+
+	item, priority, found := q.Peek()
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), "a1", item)
+	assert.Equal(suite.T(), 1, priority)
+	assert.Equal(suite.T(), 4, q.Size())
+
+	// Items of equal priority keep their order
+	assert.Equal(suite.T(), []string{"a1", "a2", "b", "c"}, suite.popAll(q))
+	assert.Equal(suite.T(), 0, q.Size())
+	assert.Equal(suite.T(), 0, q.tree.Size())
+}
+
+func (suite *PriorityQueueSuite) TestUpdatePriority() {
+	q := MakePriorityQueue[int, string]()
+	q.Push("a", 1)
+	q.Push("b", 2)
+	q.Push("c", 3)
+
+	assert.True(suite.T(), q.UpdatePriority("c", 0))
+	assert.True(suite.T(), q.UpdatePriority("a", 2))
+	assert.False(suite.T(), q.UpdatePriority("d", 0))
+
+	priority, found := q.Priority("a")
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), 2, priority)
+	_, found = q.Priority("d")
+	assert.False(suite.T(), found)
+
+	// Updated item goes after items already having the priority
+	assert.Equal(suite.T(), []string{"c", "b", "a"}, suite.popAll(q))
+}
+
+func (suite *PriorityQueueSuite) TestPushExisting() {
+	q := MakePriorityQueue[int, string]()
+	q.Push("a", 1)
+	q.Push("a", 5)
+	q.Push("b", 3)
+
+	assert.Equal(suite.T(), 2, q.Size())
+	assert.Equal(suite.T(), []string{"b", "a"}, suite.popAll(q))
+}
+
+func (suite *PriorityQueueSuite) TestRemove() {
+	q := MakePriorityQueue[int, string]()
+	q.Push("a", 1)
+	q.Push("b", 1)
+	q.Push("c", 2)
+
+	assert.True(suite.T(), q.Remove("a"))
+	assert.False(suite.T(), q.Remove("a"))
+	assert.True(suite.T(), q.Remove("c"))
+	assert.Equal(suite.T(), 1, q.tree.Size())
+
+	assert.Equal(suite.T(), []string{"b"}, suite.popAll(q))
+}
+
+func (suite *PriorityQueueSuite) TestAgainstSort() {
+	rng := rand.New(rand.NewSource(1))
+	q := MakePriorityQueue[int, int]()
+	priorities := map[int]int{}
+
+	for i := 0; i < 5000; i++ {
+		item := rng.Intn(1000)
+		switch rng.Intn(4) {
+		case 0:
+			q.Remove(item)
+			delete(priorities, item)
+		case 1:
+			if q.UpdatePriority(item, rng.Intn(100)) {
+				priorities[item], _ = q.Priority(item)
+			}
+		default:
+			p := rng.Intn(100)
+			q.Push(item, p)
+			priorities[item] = p
+		}
+	}
+	assert.Equal(suite.T(), len(priorities), q.Size())
+
+	last := -1
+	for q.Size() > 0 {
+		item, priority, _ := q.Pop()
+		assert.Equal(suite.T(), priorities[item], priority)
+		assert.LessOrEqual(suite.T(), last, priority)
+		last = priority
+		delete(priorities, item)
+	}
+	assert.Empty(suite.T(), priorities)
+}
+
+func (suite *PriorityQueueSuite) TestScheduler() {
+	clock := newFakeClock()
+	s := MakeScheduler[string](clock.Now)
+
+	_, found := s.Next()
+	assert.False(suite.T(), found)
+
+	s.After("minute", time.Minute)
+	s.After("second", time.Second)
+	s.After("hour", time.Hour)
+	s.Schedule("past", clock.Now().Add(-time.Second))
+	assert.Equal(suite.T(), 4, s.Size())
+
+	var fired []string
+	fire := func(item string) {
+		fired = append(fired, item)
+	}
+
+	assert.Equal(suite.T(), 1, s.Fire(fire))
+	assert.Equal(suite.T(), []string{"past"}, fired)
+
+	next, found := s.Next()
+	assert.True(suite.T(), found)
+	assert.True(suite.T(), clock.Now().Add(time.Second).Equal(next))
+
+	clock.Advance(time.Minute)
+	assert.Equal(suite.T(), 2, s.Fire(fire))
+	assert.Equal(suite.T(), []string{"past", "second", "minute"}, fired)
+
+	assert.True(suite.T(), s.Cancel("hour"))
+	assert.False(suite.T(), s.Cancel("hour"))
+	clock.Advance(time.Hour)
+	assert.Equal(suite.T(), 0, s.Fire(fire))
+	assert.Equal(suite.T(), 0, s.Size())
+}
+
+func (suite *PriorityQueueSuite) TestSchedulerReschedule() {
+	clock := newFakeClock()
+	s := MakeScheduler[string](clock.Now)
+
+	s.After("job", time.Second)
+	s.After("job", time.Hour)
+	clock.Advance(time.Minute)
+	assert.Equal(suite.T(), 0, s.Fire(func(string) {}))
+
+	// Items scheduled by a closure are not fired in the same call
+	clock.Advance(time.Hour)
+	count := 0
+	assert.Equal(suite.T(), 1, s.Fire(func(item string) {
+		count++
+		s.After(item, 0)
+	}))
+	assert.Equal(suite.T(), 1, count)
+	assert.Equal(suite.T(), 1, s.Size())
+}
+
+func TestPriorityQueueSuite(t *testing.T) {
+	suite.Run(t, new(PriorityQueueSuite))
+}
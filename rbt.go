@@ -3,8 +3,8 @@ package rbt
 
 import (
 	"fmt"
+	"math/bits"
 
-	"github.com/vkuksa/rbt/utils"
 	"golang.org/x/exp/constraints"
 )
 
@@ -63,11 +63,27 @@ func (node *Node[K, V]) grandparent() *Node[K, V] {
 	}
 }
 
+/*
+Function inorder applies a closure to every node of a subtree in ascending key order.
+It follows parent pointers instead of recursing, so it takes no extra memory regardless of a tree height.
+*/
 func (node *Node[K, V]) inorder(closure func(node *Node[K, V])) {
-	if node != nil {
-		node.left.inorder(closure)
-		closure(node)
-		node.right.inorder(closure)
+	for n := node.minimum(); n != nil; {
+		next := n.right.minimum()
+		if next == nil {
+			next = n
+			for next != node && next == next.parent.right {
+				next = next.parent
+			}
+			if next == node {
+				next = nil
+			} else {
+				next = next.parent
+			}
+		}
+
+		closure(n)
+		n = next
 	}
 }
 
@@ -82,28 +98,15 @@ func (node *Node[K, V]) minimum() *Node[K, V] {
 	return node
 }
 
-func (node *Node[K, V]) isBinarySearchTree(minKey, maxKey K) bool {
+func (node *Node[K, V]) maximum() *Node[K, V] {
 	if node == nil {
-		return true
-	}
-	if node.key <= minKey || node.key >= maxKey {
-		return false
-	}
-	return node.left.isBinarySearchTree(minKey, node.key) &&
-		node.right.isBinarySearchTree(node.key, maxKey)
-}
-
-func (node *Node[K, V]) hasSameBlackHeight() bool {
-	if node == nil {
-		return true
+		return nil
 	}
 
-	leftBlackHeight := node.left.getBlackHeight()
-	rightBlackHeight := node.right.getBlackHeight()
-	if leftBlackHeight != rightBlackHeight {
-		return false
+	for node.right != nil {
+		node = node.right
 	}
-	return node.left.hasSameBlackHeight() && node.right.hasSameBlackHeight()
+	return node
 }
 
 func (node *Node[K, V]) getBlackHeight() int {
@@ -121,20 +124,24 @@ func (node *Node[K, V]) getBlackHeight() int {
 	return height
 }
 
-func (node *Node[K, V]) containsConsecutiveRedNodes() bool {
-	if node == nil {
-		return false
-	}
-
-	if node.color == red && ((node.left != nil && node.left.color == red) || (node.right != nil && node.right.color == red)) {
-		return true
-	}
-	return node.left.containsConsecutiveRedNodes() || node.right.containsConsecutiveRedNodes()
-}
-
 type RedBlackTree[K constraints.Ordered, V any] struct {
 	root *Node[K, V]
 	size int
+
+	// Optional hook that recomputes augmented data of a node from its children.
+	// It is called bottom-up for every node whose subtree has changed.
+	augment func(node *Node[K, V])
+
+	// Codecs used for serialization, nil means DefaultCodec
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+
+	observers []*Observer[K, V]
+
+	// Free list of removed nodes, linked by right children, used by trees made with MakePooled
+	pooling bool
+	pool    *Node[K, V]
+	pooled  int
 }
 
 // Function Make creates empty instance of a tree
@@ -148,42 +155,40 @@ It takes key and value parameters of corresponding types.
 If key already exists - function updates it's value
 */
 func (tree *RedBlackTree[K, V]) Insert(k K, v V) {
-	n := MakeNode(k, v, red) // New node to be inserted
+	var parent *Node[K, V] // Parent of a new node
 
-	if tree.root == nil {
-		tree.root = n // Tree is empty
-	} else {
-		current := tree.root // Node to be compared against during lookup
-
-	loop:
-		for {
-			switch {
-			case k < current.key:
-				if current.left == nil {
-					current.left = n
-					break loop
-				} else {
-					current = current.left
-				}
-			case k > current.key:
-				if current.right == nil {
-					current.right = n
-					break loop
-				} else {
-					current = current.right
-				}
-			default:
-				// key already exists, update the value
-				current.value = v
-				return
-			}
+	for current := tree.root; current != nil; {
+		parent = current
+		switch {
+		case k < current.key:
+			current = current.left
+		case k > current.key:
+			current = current.right
+		default:
+			// key already exists, update the value
+			old := current.value
+			current.value = v
+			tree.notifyUpdate(k, old, v)
+			return
 		}
+	}
 
-		n.parent = current
+	// New node is created only once the key is known to be absent
+	n := tree.newNode(k, v)
+	n.parent = parent
+	if parent == nil {
+		tree.root = n // Tree is empty
+	} else if k < parent.key {
+		parent.left = n
+	} else {
+		parent.right = n
 	}
 
+	tree.refreshPath(n)
 	tree.insertFixup(n)
 	tree.size++
+	tree.check()
+	tree.notifyInsert(k, v)
 }
 
 /*
@@ -212,6 +217,8 @@ it passes a retrieved node to a helper function that deletes a node from a tree.
 func (tree *RedBlackTree[K, V]) Remove(k K) {
 	if n := tree.search(k); n != nil {
 		tree.delete(n)
+		tree.notifyRemove(n.key, n.value)
+		tree.recycle(n)
 	}
 
 	return
@@ -255,27 +262,12 @@ A red-black tree satisfies the following properties:
 	Red Property: If a red node has children then, the children are always black.
 	Depth Property: For each node, any simple path from this node to any of its descendant leaf has the same black-depth (the number of black nodes).
 
+Additionally parent pointers and the size counter have to be consistent, see Validate.
+
 Note: as this implementation does not use sentinel nodes, when tree is empty - it is not considered as valid
 */
 func (tree *RedBlackTree[K, V]) isValidRBTree() bool {
-	// Check if root is nil or not black
-	if tree.root == nil || tree.root.color != black {
-		return false
-	}
-	// Recursively check if a tree is a valid BST
-	if !tree.root.isBinarySearchTree(utils.MinValue[K](), utils.MaxValue[K]()) {
-		return false
-	}
-	// Recursively check if there are any consecutive red nodes
-	if tree.root.containsConsecutiveRedNodes() {
-		return false
-	}
-	// Recursively check that all paths from root to leaf nodes have the same black height
-	if !tree.root.hasSameBlackHeight() {
-		return false
-	}
-	// If all checks pass, the tree is valid
-	return true
+	return tree.root != nil && tree.Validate() == nil
 }
 
 func (tree *RedBlackTree[K, V]) transplant(x, y *Node[K, V]) {
@@ -304,6 +296,9 @@ func (tree *RedBlackTree[K, V]) leftRotate(n *Node[K, V]) {
 
 	r.left = n
 	n.parent = r
+
+	tree.refresh(n)
+	tree.refresh(r)
 }
 
 func (tree *RedBlackTree[K, V]) rightRotate(n *Node[K, V]) {
@@ -318,6 +313,75 @@ func (tree *RedBlackTree[K, V]) rightRotate(n *Node[K, V]) {
 
 	l.right = n
 	n.parent = l
+
+	tree.refresh(n)
+	tree.refresh(l)
+}
+
+/*
+Function rebuild replaces contents of a tree with given nodes in O(n).
+Nodes have to be sorted by key in strictly ascending order.
+Resulting tree is perfectly balanced: all nodes are black, except for the deepest level
+of an incomplete tree, which is red, so every path has the same black height.
+*/
+func (tree *RedBlackTree[K, V]) rebuild(nodes []*Node[K, V]) {
+	// Observers see replacement as removal of old entries followed by insertion of new ones
+	if len(tree.observers) > 0 {
+		tree.root.inorder(func(n *Node[K, V]) {
+			tree.notifyRemove(n.key, n.value)
+		})
+	}
+
+	tree.relink(nodes)
+
+	for _, n := range nodes {
+		tree.notifyInsert(n.key, n.value)
+	}
+}
+
+// Function relink links given nodes into a balanced tree in O(n), the same way as rebuild, but without notifications
+func (tree *RedBlackTree[K, V]) relink(nodes []*Node[K, V]) {
+	maxDepth := bits.Len(uint(len(nodes))) - 1
+	tree.root = tree.link(nodes, nil, 0, maxDepth)
+	tree.size = len(nodes)
+	tree.check()
+}
+
+func (tree *RedBlackTree[K, V]) link(nodes []*Node[K, V], parent *Node[K, V], depth, maxDepth int) *Node[K, V] {
+	if len(nodes) == 0 {
+		return nil
+	}
+
+	mid := len(nodes) / 2
+	n := nodes[mid]
+	n.parent = parent
+	n.left = tree.link(nodes[:mid], n, depth+1, maxDepth)
+	n.right = tree.link(nodes[mid+1:], n, depth+1, maxDepth)
+
+	if depth == maxDepth && depth > 0 {
+		n.color = red
+	} else {
+		n.color = black
+	}
+
+	tree.refresh(n)
+	return n
+}
+
+func (tree *RedBlackTree[K, V]) refresh(n *Node[K, V]) {
+	if tree.augment != nil {
+		tree.augment(n)
+	}
+}
+
+func (tree *RedBlackTree[K, V]) refreshPath(n *Node[K, V]) {
+	if tree.augment == nil {
+		return
+	}
+
+	for ; n != nil; n = n.parent {
+		tree.augment(n)
+	}
 }
 
 func (tree *RedBlackTree[K, V]) search(k K) *Node[K, V] {
@@ -380,49 +444,56 @@ func (tree *RedBlackTree[K, V]) insertFixup(n *Node[K, V]) {
 	tree.root.color = black
 }
 
+/*
+Function delete unlinks a node from a tree.
+Nodes are relinked rather than their payloads swapped, so every other node keeps its key and value,
+which keeps handles valid. Removed node is detached from a tree.
+*/
 func (tree *RedBlackTree[K, V]) delete(node *Node[K, V]) {
-	var x, y *Node[K, V]
-
-	// If the node to be deleted has at most one child, that node is spliced out.
-	// Otherwise, find the node's minimum and splice out that node instead.
-	if node.left == nil || node.right == nil {
-		y = node
+	var x, xParent *Node[K, V]
+
+	// y is the node which is removed from its position: the node itself if it has at most one child,
+	// otherwise its successor, which takes the place of the node
+	y := node
+	yColor := y.color
+
+	if node.left == nil {
+		x, xParent = node.right, node.parent
+		tree.transplant(node, node.right)
+	} else if node.right == nil {
+		x, xParent = node.left, node.parent
+		tree.transplant(node, node.left)
 	} else {
 		y = node.right.minimum()
-	}
-
-	// Determine the value of x
-	if y.left != nil {
-		x = y.left
-	} else {
+		yColor = y.color
 		x = y.right
-	}
 
-	// Set node's replacement
-	if x != nil {
-		x.parent = y.parent
-	}
+		if y.parent == node {
+			xParent = y
+		} else {
+			xParent = y.parent
+			tree.transplant(y, y.right)
+			y.right = node.right
+			y.right.parent = y
+		}
 
-	if y.parent == nil {
-		tree.root = x
-	} else if y == y.parent.left {
-		y.parent.left = x
-	} else {
-		y.parent.right = x
+		tree.transplant(node, y)
+		y.left = node.left
+		y.left.parent = y
+		y.color = node.color
 	}
 
-	// If the spliced-out node is not the same as the node to be deleted,
-	// overwrite the key of the node to be deleted with the key of the spliced-out node
-	if y != node {
-		node.key = y.key
-		node.value = y.value
-	}
+	// Subtrees of every ancestor of the removed position have changed
+	tree.refreshPath(xParent)
 
 	// Perform fixup of the colors after deletion
-	if y.color == black {
-		tree.deleteFixup(x, y.parent)
+	if yColor == black {
+		tree.deleteFixup(x, xParent)
 	}
+
+	node.parent, node.left, node.right = nil, nil, nil
 	tree.size--
+	tree.check()
 }
 
 func (tree *RedBlackTree[K, V]) deleteFixup(n *Node[K, V], parent *Node[K, V]) {
@@ -43,6 +43,48 @@ func (suite *NodeSuite) TestMinimum() {
 	assert.Equal(suite.T(), m.value, n.minimum().value)
 }
 
+func (suite *NodeSuite) TestMaximum() {
+	var n, m *Node[int, int]
+	assert.Nil(suite.T(), n.maximum())
+
+	n = &Node[int, int]{value: 1}
+	assert.Equal(suite.T(), 1, n.maximum().value)
+
+	m = &Node[int, int]{value: 3}
+	n.right = &Node[int, int]{value: 2}
+	n.right.right = m
+	m.parent = n.right
+	n.right.parent = n
+
+	assert.Equal(suite.T(), m.value, n.maximum().value)
+}
+
+func (suite *NodeSuite) TestInorder() {
+	var n *Node[int, int]
+	n.inorder(func(*Node[int, int]) {
+		assert.Fail(suite.T(), "nil subtree has no nodes")
+	})
+
+	tree := Make[int, int]()
+	for i := 0; i < 100; i++ {
+		tree.Insert(i, i)
+	}
+
+	// Walk of a subtree stops at its boundary
+	for _, subtree := range []*Node[int, int]{tree.root, tree.root.left, tree.root.right, tree.root.right.left} {
+		var keys []int
+		subtree.inorder(func(n *Node[int, int]) {
+			keys = append(keys, n.key)
+		})
+
+		assert.Equal(suite.T(), subtree.minimum().key, keys[0])
+		assert.Equal(suite.T(), subtree.maximum().key, keys[len(keys)-1])
+		for i := 1; i < len(keys); i++ {
+			assert.Equal(suite.T(), keys[i-1]+1, keys[i])
+		}
+	}
+}
+
 func TestNodeSuite(t *testing.T) {
 	suite.Run(t, new(NodeSuite))
 }
@@ -57,19 +99,24 @@ func (suite *RedBlackTreeSuite) TestIsValidRBTree() {
 	assert.False(suite.T(), tree.isValidRBTree())
 
 	tree.root = MakeNode(5, 5, red)
+	tree.size = 1
 	assert.False(suite.T(), tree.isValidRBTree())
 
 	tree.root = MakeNode(5, 5, black)
 	assert.True(suite.T(), tree.isValidRBTree())
 
 	tree.root.left = MakeNode(2, 2, red)
+	tree.root.left.parent = tree.root
 	tree.root.left.left = MakeNode(4, 4, red) // Violates BST and Red Property
 	tree.root.left.left.parent = tree.root.left
+	tree.size = 3
 	assert.False(suite.T(), tree.isValidRBTree())
 
 	tree.root.right = MakeNode(7, 7, red)
+	tree.root.right.parent = tree.root
 	tree.root.right.right = MakeNode(9, 9, black)
 	tree.root.right.right.parent = tree.root.right
+	tree.size = 5
 
 	tree.root.left.left.key = 1 // Still violates Red Property
 	assert.False(suite.T(), tree.isValidRBTree())
@@ -80,7 +127,15 @@ func (suite *RedBlackTreeSuite) TestIsValidRBTree() {
 	// Add missing leaves to make tree have same black depth
 	tree.root.left.right = MakeNode(3, 3, black)
 	tree.root.right.left = MakeNode(6, 6, black)
+	tree.size = 7
+	assert.False(suite.T(), tree.isValidRBTree()) // New leaves have no parent pointers
+
+	tree.root.left.right.parent = tree.root.left
+	tree.root.right.left.parent = tree.root.right
 	assert.True(suite.T(), tree.isValidRBTree())
+
+	tree.size = 6
+	assert.False(suite.T(), tree.isValidRBTree()) // Size counter does not match
 }
 
 func (suite *RedBlackTreeSuite) TestCreateEmpty() {
@@ -502,6 +557,7 @@ func BenchmarkRedBlackTreeInsert(b *testing.B) {
 	for i := iMin; i <= iMax; i++ {
 		n := 1 << i
 		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
 			tree := Make[int, int]()
 			b.ResetTimer()
 
@@ -520,6 +576,7 @@ func BenchmarkRedBlackTreeSearch(b *testing.B) {
 	for i := iMin; i <= iMax; i++ {
 		n := 1 << i
 		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
 			tree := makeFilledTree(b.N, n)
 			b.ResetTimer()
 
@@ -538,6 +595,7 @@ func BenchmarkRedBlackTreeDelete(b *testing.B) {
 	for i := iMin; i <= iMax; i++ {
 		n := 1 << i
 		b.Run(fmt.Sprintf("size_%d", n), func(b *testing.B) {
+			b.ReportAllocs()
 			tree := makeFilledTree(b.N, n)
 			b.ResetTimer()
 
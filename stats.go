@@ -0,0 +1,56 @@
+package rbt
+
+// Stats describes the shape of a tree
+type Stats struct {
+	Height       int     // Number of nodes on the longest path from the root to a leaf
+	BlackHeight  int     // Number of black nodes on any path from the root to a leaf
+	Size         int     // Number of nodes
+	Red          int     // Number of red nodes
+	Black        int     // Number of black nodes
+	AverageDepth float64 // Average distance from the root to a node, root has depth 0
+	Levels       []int   // Number of nodes at each depth, starting with the root
+}
+
+/*
+Function Stats gathers statistics about the shape of a tree.
+It visits every node level by level, so it takes O(n) time.
+*/
+func (tree *RedBlackTree[K, V]) Stats() Stats {
+	stats := Stats{BlackHeight: tree.root.getBlackHeight()}
+	totalDepth := 0
+
+	var level []*Node[K, V]
+	if tree.root != nil {
+		level = append(level, tree.root)
+	}
+
+	for depth := 0; len(level) > 0; depth++ {
+		stats.Levels = append(stats.Levels, len(level))
+		totalDepth += depth * len(level)
+
+		next := make([]*Node[K, V], 0, 2*len(level))
+		for _, n := range level {
+			if n.color == red {
+				stats.Red++
+			} else {
+				stats.Black++
+			}
+
+			if n.left != nil {
+				next = append(next, n.left)
+			}
+			if n.right != nil {
+				next = append(next, n.right)
+			}
+		}
+		level = next
+	}
+
+	stats.Height = len(stats.Levels)
+	stats.Size = stats.Red + stats.Black
+	if stats.Size > 0 {
+		stats.AverageDepth = float64(totalDepth) / float64(stats.Size)
+	}
+
+	return stats
+}
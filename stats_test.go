@@ -0,0 +1,69 @@
+package rbt
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StatsSuite struct {
+	suite.Suite
+}
+
+func (suite *StatsSuite) TestEmpty() {
+	assert.Equal(suite.T(), Stats{}, Make[int, int]().Stats())
+}
+
+func (suite *StatsSuite) TestShape() {
+	tree := Make[int, int]()
+	for _, k := range []int{8, 18, 5, 15, 17, 25, 40, 80, 3, 1, -3, 60} {
+		tree.Insert(k, k)
+	}
+
+	// Expected tree view at this moment:
+	// 				17(B)
+	//			  /	     \
+	// 		   8(B)	    25(B)
+	//		  /   \     /	\
+	//	   3(R) 15(B) 18(B) 60(B)
+	//	  /	  \			    /	\
+	//	 1(B) 5(B)		40(R)	 80(R)
+	//   /
+	// -3(R)
+
+	assert.Equal(suite.T(), Stats{
+		Height:       5,
+		BlackHeight:  3,
+		Size:         12,
+		Red:          4,
+		Black:        8,
+		AverageDepth: float64(0*1+1*2+2*4+3*4+4*1) / 12,
+		Levels:       []int{1, 2, 4, 4, 1},
+	}, tree.Stats())
+}
+
+func (suite *StatsSuite) TestHeightBound() {
+	const n = 1 << 12
+	tree := Make[int, int]()
+	for i := 0; i < n; i++ {
+		tree.Insert(i, i)
+	}
+
+	stats := tree.Stats()
+	assert.Equal(suite.T(), n, stats.Size)
+	assert.Equal(suite.T(), tree.Size(), stats.Size)
+	assert.LessOrEqual(suite.T(), float64(stats.Height), 2*math.Log2(n+1))
+	assert.Less(suite.T(), stats.AverageDepth, float64(stats.Height))
+
+	total := 0
+	for _, c := range stats.Levels {
+		total += c
+	}
+	assert.Equal(suite.T(), n, total)
+}
+
+func TestStatsSuite(t *testing.T) {
+	suite.Run(t, new(StatsSuite))
+}
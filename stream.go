@@ -0,0 +1,269 @@
+package rbt
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/crc32"
+	"io"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Streamed tree starts with a magic and a format version
+var streamMagic = [...]byte{'R', 'B', 'S'}
+
+const (
+	streamVersion = 1
+
+	// Default number of entries per chunk
+	DefaultChunkSize = 1024
+)
+
+/*
+StreamEncoder writes a tree into an io.Writer in ascending key order, without serializing it as a whole.
+
+Stream consists of a header (magic "RBS" and a version byte) and a sequence of chunks.
+Every chunk holds an index of its first entry and a number of entries as uvarints,
+entries encoded by codecs, and a big-endian CRC-32 (IEEE) of the chunk.
+Stream is terminated by an empty chunk.
+*/
+type StreamEncoder[K constraints.Ordered, V any] struct {
+	w          io.Writer
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+
+	// Maximum number of entries per chunk, DefaultChunkSize if not positive
+	ChunkSize int
+	// Optional callback, called with a total number of written entries after every chunk
+	Progress func(written int)
+}
+
+// Function NewStreamEncoder creates an encoder which writes into w using default codecs
+func NewStreamEncoder[K constraints.Ordered, V any](w io.Writer) *StreamEncoder[K, V] {
+	return &StreamEncoder[K, V]{
+		w:          w,
+		keyCodec:   DefaultCodec[K](),
+		valueCodec: DefaultCodec[V](),
+	}
+}
+
+// Function SetCodecs sets codecs used to encode keys and values. Nil codec resets to DefaultCodec
+func (e *StreamEncoder[K, V]) SetCodecs(keyCodec Codec[K], valueCodec Codec[V]) {
+	e.keyCodec, e.valueCodec = withDefaultCodecs(keyCodec, valueCodec)
+}
+
+/*
+Function Encode writes a whole tree as a stream.
+Only a single chunk is kept in memory at any time.
+*/
+func (e *StreamEncoder[K, V]) Encode(tree *RedBlackTree[K, V]) error {
+	chunkSize := e.ChunkSize
+	if chunkSize <= 0 {
+		chunkSize = DefaultChunkSize
+	}
+
+	header := [...]byte{streamMagic[0], streamMagic[1], streamMagic[2], streamVersion}
+	if _, err := e.w.Write(header[:]); err != nil {
+		return err
+	}
+
+	var chunk bytes.Buffer
+	written, count := 0, 0
+	flush := func() error {
+		var header []byte
+		header = binary.AppendUvarint(header, uint64(written))
+		header = binary.AppendUvarint(header, uint64(count))
+
+		h := crc32.NewIEEE()
+		h.Write(header)
+		h.Write(chunk.Bytes())
+
+		for _, data := range [][]byte{header, chunk.Bytes(), binary.BigEndian.AppendUint32(nil, h.Sum32())} {
+			if _, err := e.w.Write(data); err != nil {
+				return err
+			}
+		}
+
+		if count > 0 && e.Progress != nil {
+			e.Progress(written + count)
+		}
+		written += count
+		count = 0
+		chunk.Reset()
+		return nil
+	}
+
+	var err error
+	tree.root.inorder(func(n *Node[K, V]) {
+		if err != nil {
+			return
+		}
+		if err = e.keyCodec.Encode(&chunk, n.key); err != nil {
+			return
+		}
+		if err = e.valueCodec.Encode(&chunk, n.value); err != nil {
+			return
+		}
+
+		if count++; count == chunkSize {
+			err = flush()
+		}
+	})
+	if err != nil {
+		return err
+	}
+
+	if count > 0 {
+		if err := flush(); err != nil {
+			return err
+		}
+	}
+	// Terminating empty chunk
+	return flush()
+}
+
+/*
+StreamDecoder reads a stream written by StreamEncoder and inserts entries into a tree incrementally,
+one verified chunk at a time.
+
+If decoding fails, the tree holds all entries of successfully decoded chunks.
+Loading can be resumed with a new reader, positioned at Offset of the original stream.
+*/
+type StreamDecoder[K constraints.Ordered, V any] struct {
+	r          *bufio.Reader
+	keyCodec   Codec[K]
+	valueCodec Codec[V]
+
+	started bool  // Whether header is read
+	done    bool  // Whether terminating chunk is read
+	loaded  int   // Number of loaded entries
+	offset  int64 // Stream offset right after the last decoded chunk
+	last    K     // Last loaded key, to check key order across chunks
+
+	// Optional callback, called with a total number of loaded entries after every chunk
+	Progress func(loaded int)
+}
+
+// Function NewStreamDecoder creates a decoder which reads from r using default codecs
+func NewStreamDecoder[K constraints.Ordered, V any](r io.Reader) *StreamDecoder[K, V] {
+	return &StreamDecoder[K, V]{
+		r:          bufio.NewReader(r),
+		keyCodec:   DefaultCodec[K](),
+		valueCodec: DefaultCodec[V](),
+	}
+}
+
+// Function SetCodecs sets codecs used to decode keys and values. Nil codec resets to DefaultCodec
+func (d *StreamDecoder[K, V]) SetCodecs(keyCodec Codec[K], valueCodec Codec[V]) {
+	d.keyCodec, d.valueCodec = withDefaultCodecs(keyCodec, valueCodec)
+}
+
+// Function Loaded returns a number of entries inserted into a tree so far
+func (d *StreamDecoder[K, V]) Loaded() int {
+	return d.loaded
+}
+
+// Function Offset returns a stream offset in bytes, from which decoding would continue
+func (d *StreamDecoder[K, V]) Offset() int64 {
+	return d.offset
+}
+
+/*
+Function Resume replaces a source of a stream after a failure.
+New reader has to be positioned at Offset of the original stream.
+*/
+func (d *StreamDecoder[K, V]) Resume(r io.Reader) {
+	d.r = bufio.NewReader(r)
+}
+
+/*
+Function Decode reads the rest of a stream, inserting entries into a tree.
+It returns nil once the whole stream is loaded.
+*/
+func (d *StreamDecoder[K, V]) Decode(tree *RedBlackTree[K, V]) error {
+	if !d.started {
+		var header [len(streamMagic) + 1]byte
+		if _, err := io.ReadFull(d.r, header[:]); err != nil {
+			return noEOF(err)
+		}
+		if !bytes.Equal(header[:len(streamMagic)], streamMagic[:]) {
+			return fmt.Errorf("%w: bad stream header", ErrInvalidFormat)
+		}
+		if version := header[len(streamMagic)]; version != streamVersion {
+			return fmt.Errorf("%w: %d", ErrUnsupportedVersion, version)
+		}
+
+		d.started = true
+		d.offset += int64(len(header))
+	}
+
+	for !d.done {
+		if err := d.decodeChunk(tree); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (d *StreamDecoder[K, V]) decodeChunk(tree *RedBlackTree[K, V]) error {
+	r := &checksumReader{r: d.r, hash: crc32.NewIEEE()}
+
+	first, err := binary.ReadUvarint(r)
+	if err != nil {
+		return noEOF(err)
+	}
+	count, err := binary.ReadUvarint(r)
+	if err != nil {
+		return noEOF(err)
+	}
+	if first != uint64(d.loaded) {
+		return fmt.Errorf("%w: chunk starts at entry %d, but %d entries are loaded", ErrInvalidFormat, first, d.loaded)
+	}
+
+	// Chunk is decoded as a whole, so that a tree never holds unverified entries
+	type entry struct {
+		key   K
+		value V
+	}
+	var entries []entry
+	last := d.last
+	for i := uint64(0); i < count; i++ {
+		k, err := d.keyCodec.Decode(r)
+		if err != nil {
+			return noEOF(err)
+		}
+		v, err := d.valueCodec.Decode(r)
+		if err != nil {
+			return noEOF(err)
+		}
+		if (d.loaded > 0 || i > 0) && !(k > last) {
+			return fmt.Errorf("%w: keys are not in ascending order at entry %d", ErrInvalidFormat, d.loaded+int(i))
+		}
+		entries = append(entries, entry{k, v})
+		last = k
+	}
+
+	sum := r.hash.Sum32()
+	var expected [4]byte
+	if _, err := io.ReadFull(d.r, expected[:]); err != nil {
+		return noEOF(err)
+	}
+	if binary.BigEndian.Uint32(expected[:]) != sum {
+		return ErrChecksum
+	}
+
+	for _, e := range entries {
+		tree.Insert(e.key, e.value)
+	}
+	d.loaded += len(entries)
+	d.last = last
+
+	d.offset += r.n + int64(len(expected))
+	d.done = count == 0
+	if d.Progress != nil && !d.done {
+		d.Progress(d.loaded)
+	}
+	return nil
+}
@@ -0,0 +1,161 @@
+package rbt
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"strconv"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type StreamSuite struct {
+	suite.Suite
+}
+
+// Reader which fails after a given number of bytes
+type brokenReader struct {
+	r    io.Reader
+	left int
+}
+
+func (br *brokenReader) Read(p []byte) (int, error) {
+	if br.left == 0 {
+		return 0, errors.New("connection reset")
+	}
+	if len(p) > br.left {
+		p = p[:br.left]
+	}
+	n, err := br.r.Read(p)
+	br.left -= n
+	return n, err
+}
+
+func makeStreamedTree(n int) *RedBlackTree[int, string] {
+	tree := Make[int, string]()
+	for i := 0; i < n; i++ {
+		tree.Insert(i*2, strconv.Itoa(i))
+	}
+	return tree
+}
+
+func (suite *StreamSuite) TestRoundTrip() {
+	for _, n := range []int{0, 1, 99, 100, 101, 1000} {
+		tree := makeStreamedTree(n)
+
+		var buf bytes.Buffer
+		enc := NewStreamEncoder[int, string](&buf)
+		enc.ChunkSize = 100
+		progress := []int{}
+		enc.Progress = func(written int) { progress = append(progress, written) }
+		assert.NoError(suite.T(), enc.Encode(tree))
+		assert.Len(suite.T(), progress, (n+99)/100)
+
+		loaded := Make[int, string]()
+		dec := NewStreamDecoder[int, string](&buf)
+		assert.NoError(suite.T(), dec.Decode(loaded))
+		assert.NoError(suite.T(), loaded.Validate())
+		assert.Equal(suite.T(), tree.Keys(), loaded.Keys())
+		assert.Equal(suite.T(), n, dec.Loaded())
+
+		// Decoding a fully loaded stream is a no-op
+		assert.NoError(suite.T(), dec.Decode(loaded))
+	}
+}
+
+func (suite *StreamSuite) TestResume() {
+	tree := makeStreamedTree(1000)
+
+	var buf bytes.Buffer
+	enc := NewStreamEncoder[int, string](&buf)
+	enc.ChunkSize = 64
+	assert.NoError(suite.T(), enc.Encode(tree))
+	data := buf.Bytes()
+
+	loaded := Make[int, string]()
+	dec := NewStreamDecoder[int, string](&brokenReader{r: bytes.NewReader(data), left: len(data) / 3})
+	progress := []int{}
+	dec.Progress = func(loaded int) { progress = append(progress, loaded) }
+
+	assert.Error(suite.T(), dec.Decode(loaded))
+	assert.Greater(suite.T(), dec.Loaded(), 0)
+	assert.Less(suite.T(), dec.Loaded(), 1000)
+	assert.Equal(suite.T(), 0, dec.Loaded()%64, "only complete chunks are loaded")
+	assert.Equal(suite.T(), dec.Loaded(), loaded.Size())
+	assert.Equal(suite.T(), dec.Loaded(), progress[len(progress)-1])
+
+	// Keep resuming from a reader positioned at the offset
+	for i := 0; i < 10; i++ {
+		offset := dec.Offset()
+		dec.Resume(&brokenReader{r: bytes.NewReader(data[offset:]), left: len(data) / 3})
+		if err := dec.Decode(loaded); err == nil {
+			break
+		}
+		assert.Greater(suite.T(), dec.Offset(), offset)
+	}
+
+	assert.Equal(suite.T(), 1000, dec.Loaded())
+	assert.NoError(suite.T(), loaded.Validate())
+	assert.Equal(suite.T(), tree.Keys(), loaded.Keys())
+}
+
+func (suite *StreamSuite) TestCorrupted() {
+	tree := makeStreamedTree(10)
+	var buf bytes.Buffer
+	enc := NewStreamEncoder[int, string](&buf)
+	enc.ChunkSize = 4
+	assert.NoError(suite.T(), enc.Encode(tree))
+	data := buf.Bytes()
+
+	// Damage the last byte of the second chunk's data
+	corrupted := append([]byte{}, data...)
+	dec := NewStreamDecoder[int, string](bytes.NewReader(data))
+	chunks := []int64{}
+	dec.Progress = func(int) { chunks = append(chunks, dec.offset) }
+	assert.NoError(suite.T(), dec.Decode(Make[int, string]()))
+	corrupted[chunks[1]-1] ^= 0xff
+
+	loaded := Make[int, string]()
+	dec = NewStreamDecoder[int, string](bytes.NewReader(corrupted))
+	assert.ErrorIs(suite.T(), dec.Decode(loaded), ErrChecksum)
+	assert.Equal(suite.T(), []int{0, 2, 4, 6}, loaded.Keys())
+
+	// Stream without terminating chunk is incomplete
+	dec = NewStreamDecoder[int, string](bytes.NewReader(data[:chunks[2]]))
+	assert.ErrorIs(suite.T(), dec.Decode(Make[int, string]()), io.ErrUnexpectedEOF)
+
+	dec = NewStreamDecoder[int, string](bytes.NewReader([]byte("RBT\x01")))
+	assert.ErrorIs(suite.T(), dec.Decode(loaded), ErrInvalidFormat)
+	dec = NewStreamDecoder[int, string](bytes.NewReader([]byte("RBS\x09")))
+	assert.ErrorIs(suite.T(), dec.Decode(loaded), ErrUnsupportedVersion)
+
+	// Resuming at a wrong chunk is detected
+	dec = NewStreamDecoder[int, string](bytes.NewReader(data[:chunks[0]]))
+	assert.Error(suite.T(), dec.Decode(Make[int, string]()))
+	dec.Resume(bytes.NewReader(data[chunks[1]:]))
+	assert.ErrorIs(suite.T(), dec.Decode(Make[int, string]()), ErrInvalidFormat)
+}
+
+func (suite *StreamSuite) TestCustomCodec() {
+	tree := Make[int, int]()
+	tree.Insert(1, 10)
+	tree.Insert(2, 20)
+
+	var buf bytes.Buffer
+	enc := NewStreamEncoder[int, int](&buf)
+	enc.SetCodecs(fixedCodec{}, nil)
+	assert.NoError(suite.T(), enc.Encode(tree))
+
+	loaded := Make[int, int]()
+	dec := NewStreamDecoder[int, int](&buf)
+	dec.SetCodecs(fixedCodec{}, nil)
+	assert.NoError(suite.T(), dec.Decode(loaded))
+	v, _ := loaded.Search(2)
+	assert.Equal(suite.T(), 20, v)
+}
+
+func TestStreamSuite(t *testing.T) {
+	suite.Run(t, new(StreamSuite))
+}
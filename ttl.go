@@ -0,0 +1,150 @@
+package rbt
+
+import (
+	"time"
+
+	"golang.org/x/exp/constraints"
+)
+
+type expiringEntry[V any] struct {
+	value    V
+	deadline int64 // Unix time in nanoseconds, meaningful only if entry expires
+	expires  bool
+}
+
+/*
+ExpiringTree is a tree, which entries may have a time-to-live.
+Expired entries are invisible to lookups and traversals, and are removed by Expire,
+which uses a secondary index of entries ordered by expiration time.
+*/
+type ExpiringTree[K constraints.Ordered, V any] struct {
+	tree      *RedBlackTree[K, expiringEntry[V]]
+	deadlines *RedBlackTree[int64, []K]
+	now       func() time.Time
+}
+
+/*
+Function MakeExpiring creates empty instance of an expiring tree.
+It takes a clock, which is used to check expiration. Nil clock means time.Now.
+*/
+func MakeExpiring[K constraints.Ordered, V any](now func() time.Time) *ExpiringTree[K, V] {
+	if now == nil {
+		now = time.Now
+	}
+
+	return &ExpiringTree[K, V]{
+		tree:      Make[K, expiringEntry[V]](),
+		deadlines: Make[int64, []K](),
+		now:       now,
+	}
+}
+
+// Function Insert puts a value, which never expires, into a tree. If key already exists - function updates it's value
+func (et *ExpiringTree[K, V]) Insert(k K, v V) {
+	et.insert(k, expiringEntry[V]{value: v})
+}
+
+/*
+Function InsertWithTTL puts a value, which expires after a given duration, into a tree.
+If key already exists - function updates it's value and time-to-live.
+*/
+func (et *ExpiringTree[K, V]) InsertWithTTL(k K, v V, ttl time.Duration) {
+	et.insert(k, expiringEntry[V]{value: v, deadline: et.now().Add(ttl).UnixNano(), expires: true})
+}
+
+func (et *ExpiringTree[K, V]) insert(k K, e expiringEntry[V]) {
+	if n := et.tree.search(k); n != nil {
+		et.unindex(k, n.value)
+	}
+
+	et.tree.Insert(k, e)
+	if e.expires {
+		if n := et.deadlines.search(e.deadline); n != nil {
+			n.value = append(n.value, k)
+		} else {
+			et.deadlines.Insert(e.deadline, []K{k})
+		}
+	}
+}
+
+// Function unindex removes a key of an entry from the expiration index
+func (et *ExpiringTree[K, V]) unindex(k K, e expiringEntry[V]) {
+	if !e.expires {
+		return
+	}
+
+	n := et.deadlines.search(e.deadline)
+	for i, key := range n.value {
+		if key == k {
+			n.value[i] = n.value[len(n.value)-1]
+			n.value = n.value[:len(n.value)-1]
+			break
+		}
+	}
+	if len(n.value) == 0 {
+		et.deadlines.delete(n)
+	}
+}
+
+func (et *ExpiringTree[K, V]) isExpired(e expiringEntry[V], now int64) bool {
+	return e.expires && e.deadline <= now
+}
+
+// Function Search performs lookup of a value by key, expired entries are not found
+func (et *ExpiringTree[K, V]) Search(k K) (value V, exists bool) {
+	n := et.tree.search(k)
+	if n == nil || et.isExpired(n.value, et.now().UnixNano()) {
+		return
+	}
+	return n.value.value, true
+}
+
+// Function Remove removes given key from a tree
+func (et *ExpiringTree[K, V]) Remove(k K) {
+	if n := et.tree.search(k); n != nil {
+		et.unindex(k, n.value)
+		et.tree.Remove(k)
+	}
+}
+
+// Function Keys returns a collection of keys of entries, which are not expired, in ascending order
+func (et *ExpiringTree[K, V]) Keys() []K {
+	result := make([]K, 0, et.tree.Size())
+	et.Traverse(func(k K, v V) {
+		result = append(result, k)
+	})
+	return result
+}
+
+// Function Traverse applies a closure to every entry, which is not expired, in ascending key order
+func (et *ExpiringTree[K, V]) Traverse(closure func(k K, v V)) {
+	now := et.now().UnixNano()
+	et.tree.Traverse(func(k K, e expiringEntry[V]) {
+		if !et.isExpired(e, now) {
+			closure(k, e.value)
+		}
+	})
+}
+
+// Function Size returns a number of stored entries, including expired ones, which are not removed yet
+func (et *ExpiringTree[K, V]) Size() int {
+	return et.tree.Size()
+}
+
+/*
+Function Expire removes all entries, which are expired at a given time, and returns their number.
+It takes O(m log n) time for m expired entries.
+*/
+func (et *ExpiringTree[K, V]) Expire(now time.Time) int {
+	deadline := now.UnixNano()
+	removed := 0
+
+	for n := et.deadlines.root.minimum(); n != nil && n.key <= deadline; n = et.deadlines.root.minimum() {
+		for _, k := range n.value {
+			et.tree.Remove(k)
+		}
+		removed += len(n.value)
+		et.deadlines.delete(n)
+	}
+	return removed
+}
@@ -0,0 +1,132 @@
+package rbt
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ExpiringTreeSuite struct {
+	suite.Suite
+}
+
+// Clock, which only moves when told to
+type fakeClock struct {
+	now time.Time
+}
+
+func (c *fakeClock) Now() time.Time {
+	return c.now
+}
+
+func (c *fakeClock) Advance(d time.Duration) {
+	c.now = c.now.Add(d)
+}
+
+func newFakeClock() *fakeClock {
+	return &fakeClock{now: time.Date(2023, 3, 1, 0, 0, 0, 0, time.UTC)}
+}
+
+func (suite *ExpiringTreeSuite) TestExpiration() {
+	clock := newFakeClock()
+	tree := MakeExpiring[string, int](clock.Now)
+
+	tree.Insert("forever", 0)
+	tree.InsertWithTTL("short", 1, time.Second)
+	tree.InsertWithTTL("long", 2, time.Minute)
+
+	v, found := tree.Search("short")
+	assert.True(suite.T(), found)
+	assert.Equal(suite.T(), 1, v)
+	assert.Equal(suite.T(), []string{"forever", "long", "short"}, tree.Keys())
+
+	clock.Advance(time.Second)
+	_, found = tree.Search("short")
+	assert.False(suite.T(), found)
+	assert.Equal(suite.T(), []string{"forever", "long"}, tree.Keys())
+	// Expired entry is still stored until swept
+	assert.Equal(suite.T(), 3, tree.Size())
+
+	assert.Equal(suite.T(), 1, tree.Expire(clock.Now()))
+	assert.Equal(suite.T(), 2, tree.Size())
+	assert.Equal(suite.T(), 0, tree.Expire(clock.Now()))
+
+	assert.Equal(suite.T(), 1, tree.Expire(clock.Now().Add(time.Hour)))
+	assert.Equal(suite.T(), []string{"forever"}, tree.Keys())
+	assert.Equal(suite.T(), 0, tree.deadlines.Size())
+}
+
+func (suite *ExpiringTreeSuite) TestUpdateTTL() {
+	clock := newFakeClock()
+	tree := MakeExpiring[int, string](clock.Now)
+
+	tree.InsertWithTTL(1, "a", time.Second)
+	tree.InsertWithTTL(2, "b", time.Second)
+	tree.InsertWithTTL(1, "c", time.Hour)
+	tree.InsertWithTTL(2, "d", time.Second)
+	tree.Insert(2, "e")
+
+	clock.Advance(time.Minute)
+	assert.Equal(suite.T(), 0, tree.Expire(clock.Now()))
+	assert.Equal(suite.T(), []int{1, 2}, tree.Keys())
+
+	v, _ := tree.Search(1)
+	assert.Equal(suite.T(), "c", v)
+	assert.Equal(suite.T(), 1, tree.deadlines.Size())
+
+	tree.Remove(1)
+	assert.Equal(suite.T(), 0, tree.deadlines.Size())
+	assert.Equal(suite.T(), 0, tree.Expire(clock.Now().Add(time.Hour)))
+	assert.Equal(suite.T(), []int{2}, tree.Keys())
+}
+
+func (suite *ExpiringTreeSuite) TestSweep() {
+	clock := newFakeClock()
+	tree := MakeExpiring[int, int](clock.Now)
+
+	for i := 0; i < 1000; i++ {
+		tree.InsertWithTTL(i, i, time.Duration(i%10)*time.Second+time.Millisecond)
+	}
+
+	clock.Advance(5 * time.Second)
+	assert.Equal(suite.T(), 500, tree.Expire(clock.Now()))
+	assert.Equal(suite.T(), 500, tree.Size())
+
+	visited := 0
+	tree.Traverse(func(k, v int) {
+		assert.GreaterOrEqual(suite.T(), k%10, 5)
+		visited++
+	})
+	assert.Equal(suite.T(), 500, visited)
+	assert.NoError(suite.T(), tree.tree.Validate())
+	assert.NoError(suite.T(), tree.deadlines.Validate())
+}
+
+func (suite *ExpiringTreeSuite) TestEpochDeadline() {
+	clock := &fakeClock{now: time.Unix(0, 0)}
+	tree := MakeExpiring[string, int](clock.Now)
+
+	tree.InsertWithTTL("now", 1, 0)
+	tree.InsertWithTTL("later", 2, time.Second)
+	clock.Advance(-time.Second)
+	tree.InsertWithTTL("epoch", 3, time.Second)
+	tree.Insert("forever", 4)
+
+	assert.Equal(suite.T(), []string{"epoch", "forever", "later", "now"}, tree.Keys())
+	clock.Advance(time.Second)
+	_, found := tree.Search("now")
+	assert.False(suite.T(), found)
+	_, found = tree.Search("epoch")
+	assert.False(suite.T(), found)
+
+	assert.Equal(suite.T(), 2, tree.Expire(clock.Now()))
+	assert.Equal(suite.T(), []string{"forever", "later"}, tree.Keys())
+	assert.Equal(suite.T(), 1, tree.Expire(clock.Now().Add(time.Hour)))
+	assert.Equal(suite.T(), []string{"forever"}, tree.Keys())
+}
+
+func TestExpiringTreeSuite(t *testing.T) {
+	suite.Run(t, new(ExpiringTreeSuite))
+}
@@ -0,0 +1,162 @@
+package rbt
+
+import (
+	"errors"
+	"fmt"
+
+	"golang.org/x/exp/constraints"
+)
+
+// Invariant violations reported by Validate
+var (
+	ErrRootColor   = errors.New("root is not black")
+	ErrOrder       = errors.New("binary search tree order is violated")
+	ErrRedRed      = errors.New("red node has a red child")
+	ErrBlackHeight = errors.New("black height mismatch")
+	ErrParentLink  = errors.New("parent pointer mismatch")
+	ErrSize        = errors.New("size counter mismatch")
+)
+
+/*
+ValidationError describes a violated invariant together with the key of the offending node.
+It unwraps to one of the Err* values, so it can be checked with errors.Is.
+*/
+type ValidationError[K constraints.Ordered] struct {
+	Err error
+	Key K
+}
+
+func (e *ValidationError[K]) Error() string {
+	return fmt.Sprintf("%v at key %v", e.Err, e.Key)
+}
+
+func (e *ValidationError[K]) Unwrap() error {
+	return e.Err
+}
+
+/*
+Function Validate checks whether a tree satisfies Red-Black and BST tree properties,
+and whether its bookkeeping is consistent.
+
+It returns nil for a healthy tree (including an empty one), otherwise an error which describes
+the first violation found:
+
+	ErrRootColor: the root is not black.
+	ErrOrder: a key is not greater than the previous key in order.
+	ErrRedRed: a red node has a red child.
+	ErrBlackHeight: subtrees of a node have different black heights.
+	ErrParentLink: a child does not point back to its parent, or the root has a parent.
+	ErrSize: the size counter does not match the actual number of nodes.
+
+All but ErrSize are reported as *ValidationError holding the key of the offending node.
+*/
+func (tree *RedBlackTree[K, V]) Validate() error {
+	if root := tree.root; root != nil {
+		if root.color != black {
+			return &ValidationError[K]{Err: ErrRootColor, Key: root.key}
+		}
+		if root.parent != nil {
+			return &ValidationError[K]{Err: ErrParentLink, Key: root.key}
+		}
+	}
+
+	count, err := tree.root.validate()
+	if err != nil {
+		return err
+	}
+	if count != tree.size {
+		return fmt.Errorf("%w: counter is %d, actual number of nodes is %d", ErrSize, tree.size, count)
+	}
+	return nil
+}
+
+// Directions of a walk over a tree
+const (
+	walkDown      = iota // Node is entered from its parent
+	walkFromLeft         // Left subtree of a node is done
+	walkFromRight        // Right subtree of a node is done
+)
+
+/*
+Function validate checks a tree in a single O(n) pass and returns a number of its nodes.
+It walks the tree following parent pointers, each of which is verified before it is followed,
+so the only memory it takes is a stack of black heights of left subtrees along the current path.
+Order of keys is checked by comparing each key to the previous one in order.
+*/
+func (node *Node[K, V]) validate() (int, error) {
+	var (
+		count  int
+		prev   *K  // Previous key in order
+		height int // Black height of the subtree which is just done
+		// Black heights of left subtrees of the nodes whose right subtrees are walked.
+		// Height of a valid tree never exceeds twice the number of bits of its size.
+		buf     [128]int
+		heights = buf[:0]
+	)
+
+	for n, dir := node, walkDown; n != nil; {
+		switch dir {
+		case walkDown:
+			count++
+			for _, child := range [...]*Node[K, V]{n.left, n.right} {
+				if child == nil {
+					continue
+				}
+				if child.parent != n {
+					return 0, &ValidationError[K]{Err: ErrParentLink, Key: child.key}
+				}
+				if n.color == red && child.color == red {
+					return 0, &ValidationError[K]{Err: ErrRedRed, Key: n.key}
+				}
+			}
+
+			if n.left != nil {
+				n = n.left
+			} else {
+				height, dir = 0, walkFromLeft
+			}
+
+		case walkFromLeft:
+			if prev != nil && !(n.key > *prev) {
+				return 0, &ValidationError[K]{Err: ErrOrder, Key: n.key}
+			}
+			prev = &n.key
+
+			heights = append(heights, height)
+			if n.right != nil {
+				n, dir = n.right, walkDown
+			} else {
+				height, dir = 0, walkFromRight
+			}
+
+		case walkFromRight:
+			left := heights[len(heights)-1]
+			heights = heights[:len(heights)-1]
+			if left != height {
+				return 0, &ValidationError[K]{Err: ErrBlackHeight, Key: n.key}
+			}
+			if n.color == black {
+				height++
+			}
+
+			if n == node {
+				n = nil
+			} else if n == n.parent.left {
+				n, dir = n.parent, walkFromLeft
+			} else {
+				n = n.parent
+			}
+		}
+	}
+
+	return count, nil
+}
+
+// Function check panics if a tree is broken. It is a no-op unless built with the rbtdebug tag
+func (tree *RedBlackTree[K, V]) check() {
+	if debug {
+		if err := tree.Validate(); err != nil {
+			panic(err)
+		}
+	}
+}
@@ -0,0 +1,194 @@
+package rbt
+
+import (
+	"errors"
+	"math"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/suite"
+)
+
+type ValidateSuite struct {
+	suite.Suite
+}
+
+func makeValidatedTree(keys ...int) *RedBlackTree[int, int] {
+	tree := Make[int, int]()
+	for _, k := range keys {
+		tree.Insert(k, k)
+	}
+	return tree
+}
+
+func (suite *ValidateSuite) assertViolation(err, expected error, key int) {
+	assert.ErrorIs(suite.T(), err, expected)
+
+	var verr *ValidationError[int]
+	if assert.True(suite.T(), errors.As(err, &verr)) {
+		assert.Equal(suite.T(), key, verr.Key)
+	}
+}
+
+func (suite *ValidateSuite) TestValid() {
+	assert.NoError(suite.T(), Make[int, int]().Validate())
+
+	tree := makeValidatedTree(8, 18, 5, 15, 17, 25, 40, 80, 3, 1, -3, 60)
+	assert.NoError(suite.T(), tree.Validate())
+
+	for _, k := range []int{8, 80, 60, 5, 1} {
+		tree.Remove(k)
+		assert.NoError(suite.T(), tree.Validate())
+	}
+}
+
+func (suite *ValidateSuite) TestRootColor() {
+	tree := makeValidatedTree(5)
+	tree.root.color = red
+
+	suite.assertViolation(tree.Validate(), ErrRootColor, 5)
+}
+
+func (suite *ValidateSuite) TestOrder() {
+	// Expected tree view:
+	// 				2(B)
+	//			  /		 \
+	// 			1(R)	 3(R)
+	tree := makeValidatedTree(1, 2, 3)
+	tree.root.right.key = 0
+
+	suite.assertViolation(tree.Validate(), ErrOrder, 0)
+}
+
+func (suite *ValidateSuite) TestRedRed() {
+	tree := makeValidatedTree(1, 2, 3, 4)
+	// 4 is a red child of black 3
+	tree.root.right.color = red
+
+	suite.assertViolation(tree.Validate(), ErrRedRed, 3)
+}
+
+func (suite *ValidateSuite) TestBlackHeight() {
+	tree := makeValidatedTree(1, 2, 3)
+	tree.root.left.color = black
+
+	suite.assertViolation(tree.Validate(), ErrBlackHeight, 2)
+}
+
+func (suite *ValidateSuite) TestParentLink() {
+	tree := makeValidatedTree(1, 2, 3)
+	tree.root.left.parent = tree.root.right
+
+	suite.assertViolation(tree.Validate(), ErrParentLink, 1)
+
+	tree = makeValidatedTree(1, 2, 3)
+	tree.root.parent = tree.root.left
+
+	suite.assertViolation(tree.Validate(), ErrParentLink, 2)
+}
+
+func (suite *ValidateSuite) TestSize() {
+	tree := makeValidatedTree(1, 2, 3)
+	tree.size++
+
+	err := tree.Validate()
+	assert.ErrorIs(suite.T(), err, ErrSize)
+	assert.EqualError(suite.T(), err, "size counter mismatch: counter is 4, actual number of nodes is 3")
+
+	tree = Make[int, int]()
+	tree.size = 1
+	assert.ErrorIs(suite.T(), tree.Validate(), ErrSize)
+}
+
+func (suite *ValidateSuite) TestLargeTree() {
+	nodes := make([]*Node[int, int], 1<<20-1)
+	for i := range nodes {
+		nodes[i] = MakeNode(i, i, black)
+	}
+	tree := Make[int, int]()
+	tree.rebuild(nodes)
+	assert.NoError(suite.T(), tree.Validate())
+
+	// Violation deep in a tree is found as well
+	assert.Equal(suite.T(), red, tree.Last().color)
+	tree.Last().color = black
+	suite.assertViolation(tree.Validate(), ErrBlackHeight, tree.Last().parent.key)
+}
+
+type level int8
+
+func (suite *ValidateSuite) TestExtremeKeys() {
+	ints := Make[int, int]()
+	for _, k := range []int{math.MinInt, -1, 0, 1, math.MaxInt} {
+		ints.Insert(k, k)
+		assert.NoError(suite.T(), ints.Validate())
+		assert.True(suite.T(), ints.isValidRBTree())
+	}
+
+	uints := Make[uint64, int]()
+	for _, k := range []uint64{0, 1, math.MaxUint64} {
+		uints.Insert(k, 0)
+		assert.NoError(suite.T(), uints.Validate())
+		assert.True(suite.T(), uints.isValidRBTree())
+	}
+
+	floats := Make[float64, int]()
+	for _, k := range []float64{math.Inf(-1), -math.MaxFloat64, 0, math.MaxFloat64, math.Inf(1)} {
+		floats.Insert(k, 0)
+		assert.NoError(suite.T(), floats.Validate())
+		assert.True(suite.T(), floats.isValidRBTree())
+	}
+
+	levels := Make[level, int]()
+	for _, k := range []level{math.MinInt8, 0, math.MaxInt8} {
+		levels.Insert(k, 0)
+		assert.NoError(suite.T(), levels.Validate())
+		assert.True(suite.T(), levels.isValidRBTree())
+	}
+}
+
+func (suite *ValidateSuite) TestExtremeStringKeys() {
+	keys := []string{
+		"",
+		"a",
+		strings.Repeat("z", 1000),
+		strings.Repeat(string(rune(math.MaxInt32)), 300),
+		string(rune(0x10FFFF)),
+		"\xff\xff",
+	}
+
+	tree := Make[string, int]()
+	for _, k := range keys {
+		tree.Insert(k, 0)
+		assert.NoError(suite.T(), tree.Validate())
+		assert.True(suite.T(), tree.isValidRBTree())
+	}
+	assert.Equal(suite.T(), len(keys), tree.Size())
+
+	tree.Remove("")
+	tree.Remove("\xff\xff")
+	assert.NoError(suite.T(), tree.Validate())
+	assert.True(suite.T(), tree.isValidRBTree())
+}
+
+func (suite *ValidateSuite) TestOrderAtExtremeKeys() {
+	tree := Make[int, int]()
+	tree.Insert(0, 0)
+	tree.Insert(math.MaxInt, 0)
+	tree.Insert(math.MinInt, 0)
+
+	// Duplicate of a parent key is out of order even at the extremes
+	tree.root.left.key = tree.root.key
+	suite.assertViolation(tree.Validate(), ErrOrder, 0)
+	assert.False(suite.T(), tree.isValidRBTree())
+
+	tree.root.left.key = math.MinInt
+	tree.root.right.key = math.MinInt
+	suite.assertViolation(tree.Validate(), ErrOrder, math.MinInt)
+	assert.False(suite.T(), tree.isValidRBTree())
+}
+
+func TestValidateSuite(t *testing.T) {
+	suite.Run(t, new(ValidateSuite))
+}